@@ -44,6 +44,46 @@ var _ = Describe("ArgsOf", func() {
 		Expect(fields["age"].Type).To(Equal(graphql.Int))
 	})
 
+	It("should generate input objects for nested structs", func() {
+		type Address struct {
+			Street string `graphql:"!street"`
+			City   string `graphql:"city"`
+		}
+
+		type CreateUserArgs struct {
+			Name      string    `graphql:"!name"`
+			Address   Address   `graphql:"address"`
+			Addresses []Address `graphql:"addresses"`
+		}
+
+		type User struct {
+			Name string `graphql:"name"`
+		}
+
+		enc := gqlstruct.NewEncoder()
+		fields, err := enc.ArgsOf(reflect.TypeOf(CreateUserArgs{}))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(fields).To(HaveLen(3))
+		Expect(fields["address"].Type).To(BeAssignableToTypeOf(&graphql.InputObject{}))
+		Expect(fields["address"].Type.Name()).To(Equal("AddressInput"))
+		Expect(fields["addresses"].Type.String()).To(Equal("[AddressInput]"))
+
+		userType, err := enc.Struct(User{})
+		Expect(err).ToNot(HaveOccurred())
+		_, err = graphql.NewSchema(graphql.SchemaConfig{
+			Query: graphql.NewObject(graphql.ObjectConfig{
+				Name: "Query",
+				Fields: graphql.Fields{
+					"createUser": &graphql.Field{
+						Type: userType,
+						Args: fields,
+					},
+				},
+			}),
+		})
+		Expect(err).ToNot(HaveOccurred())
+	})
+
 	It("should fail generating arguments for a non supported datatype", func() {
 		_, err := gqlstruct.NewEncoder().ArgsOf(reflect.TypeOf("data"))
 		Expect(err).To(HaveOccurred())
@@ -0,0 +1,348 @@
+package gqlstruct
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/graphql-go/graphql"
+	"reflect"
+	"sync"
+)
+
+// BatchResolveFn resolves a field of many sources at once. It returns the
+// values of the sources, in the same order.
+type BatchResolveFn func(ctx context.Context, sources []interface{}) ([]interface{}, error)
+
+// GraphqlBatchResolver is the interface implemented by types that resolve
+// the fields of their type in batches (check `WithBatchResolve`).
+//
+// As `GraphqlResolver`, the method is called statically. So, do not make
+// any references of the value itself inside of it.
+type GraphqlBatchResolver interface {
+	GraphqlBatchResolve(ctx context.Context, sources []interface{}) ([]interface{}, error)
+}
+
+type withBatchResolve struct {
+	resolve BatchResolveFn
+}
+
+// WithBatchResolve creates a `FieldOption` that resolves the field in
+// batches, avoiding the N+1 lookups of nested fields (as `Order.customer`
+// of a list of orders):
+//
+// ```
+// gqlstruct.WithFieldOptions("customer", gqlstruct.WithBatchResolve(loadCustomers))
+// ```
+//
+// The sources of the field resolved by the same request (check
+// `NewBatchContext`) are collected until their values are needed, when
+// they are resolved together by a single call of resolve. The values are
+// returned through the thunks of graphql-go, as `func() (interface{},
+// error)`.
+//
+// The values are cached by the request, so the same source (compared by
+// pointers, strings and numbers) is resolved only once for the same
+// arguments. The sources are batched by the arguments of the field, which
+// are returned by `BatchArgs`.
+func WithBatchResolve(resolve BatchResolveFn) FieldOption {
+	return &withBatchResolve{
+		resolve: resolve,
+	}
+}
+
+// ApplyField sets the batch resolver of the field.
+func (option *withBatchResolve) ApplyField(field *graphql.Field) error {
+	field.Resolve = batchResolve(option.resolve)
+	return nil
+}
+
+type batchContextKey struct{}
+
+type batchArgsKey struct{}
+
+// NewBatchContext creates the context of a request that resolves fields in
+// batches, which collects and caches their values:
+//
+// ```
+// r := graphql.Do(graphql.Params{Schema: schema, RequestString: query, Context: gqlstruct.NewBatchContext(ctx)})
+// ```
+func NewBatchContext(ctx context.Context) context.Context {
+	return context.WithValue(ctx, batchContextKey{}, newBatchCache(false))
+}
+
+// BatchArgs returns the arguments of the field resolved by a batch. The
+// sources of a field are batched (and cached) by its arguments, so all
+// sources of a batch share them. Inside of a `BatchResolveFn`:
+//
+// ```
+// limit := gqlstruct.BatchArgs(ctx)["limit"]
+// ```
+func BatchArgs(ctx context.Context) map[string]interface{} {
+	args, _ := ctx.Value(batchArgsKey{}).(map[string]interface{})
+	return args
+}
+
+// BatchHarness resolves fields in batches outside of a request, for tests.
+// Its batches are resolved only by `Flush`, so the calls of the batch
+// resolvers do not depend on the order the thunks are read:
+//
+// ```
+// h := gqlstruct.NewBatchHarness(ctx)
+// first := h.Resolve(field.Resolve, orders[0], nil)
+// second := h.Resolve(field.Resolve, orders[1], nil)
+// h.Flush() // resolves both orders by a single call
+// customer, err := first()
+// ```
+type BatchHarness struct {
+	ctx   context.Context
+	cache *batchCache
+}
+
+// NewBatchHarness creates a `BatchHarness` resolving the fields with the
+// context ctx.
+func NewBatchHarness(ctx context.Context) *BatchHarness {
+	cache := newBatchCache(true)
+	return &BatchHarness{
+		ctx:   context.WithValue(ctx, batchContextKey{}, cache),
+		cache: cache,
+	}
+}
+
+// Context returns the context of the harness, to be used by resolvers
+// called directly.
+func (h *BatchHarness) Context() context.Context {
+	return h.ctx
+}
+
+// Resolve resolves the field of the source with the arguments informed and
+// returns its thunk. The values of the fields resolved in batches are only
+// available after `Flush`; before that, the thunk fails.
+func (h *BatchHarness) Resolve(resolve graphql.FieldResolveFn, source interface{}, args map[string]interface{}) func() (interface{}, error) {
+	value, err := resolve(graphql.ResolveParams{
+		Context: h.ctx,
+		Source:  source,
+		Args:    args,
+	})
+	if thunk, ok := value.(func() (interface{}, error)); ok && err == nil {
+		return thunk
+	}
+	return func() (interface{}, error) {
+		return value, err
+	}
+}
+
+// Flush resolves the batches collected, in the order they were created,
+// and returns how many were resolved. Sources resolved after that start
+// new batches.
+func (h *BatchHarness) Flush() int {
+	h.cache.mu.Lock()
+	pending := h.cache.pending
+	h.cache.pending = nil
+	for _, b := range pending {
+		delete(h.cache.batches, b.key)
+	}
+	h.cache.mu.Unlock()
+
+	for _, b := range pending {
+		b.resolve()
+	}
+
+	h.cache.mu.Lock()
+	for _, b := range pending {
+		b.flushed = true
+	}
+	h.cache.mu.Unlock()
+	return len(pending)
+}
+
+// batchCache is the state of the batches of a request.
+type batchCache struct {
+	mu sync.Mutex
+	// manual makes the batches resolved only when flushed (check
+	// `BatchHarness`).
+	manual bool
+	// batches are the batches collecting sources, pending are them in the
+	// order they were created, and entries are the entries of the sources
+	// already loaded. All of them by field and arguments.
+	batches map[batchKey]*batch
+	pending []*batch
+	entries map[batchKey]map[interface{}]*batchEntry
+}
+
+func newBatchCache(manual bool) *batchCache {
+	return &batchCache{
+		manual:  manual,
+		batches: make(map[batchKey]*batch),
+		entries: make(map[batchKey]map[interface{}]*batchEntry),
+	}
+}
+
+// batchKey identifies the field, and its arguments, of a batch.
+type batchKey struct {
+	resolver *batchResolver
+	args     string
+}
+
+// batch is a set of sources of a field resolved together.
+type batch struct {
+	key     batchKey
+	ctx     context.Context
+	once    sync.Once
+	flushed bool
+	sources []interface{}
+	values  []interface{}
+	err     error
+}
+
+// resolve resolves the sources of the batch, only once.
+func (b *batch) resolve() {
+	b.once.Do(func() {
+		b.values, b.err = b.key.resolver.resolve(b.ctx, b.sources)
+		if b.err == nil && len(b.values) != len(b.sources) {
+			b.err = fmt.Errorf("the batch resolved %d values for %d sources", len(b.values), len(b.sources))
+		}
+	})
+}
+
+// batchEntry is a source of a batch.
+type batchEntry struct {
+	batch *batch
+	index int
+}
+
+// batchResolver resolves a field in batches. Its address identifies the
+// field in the caches.
+type batchResolver struct {
+	resolve BatchResolveFn
+}
+
+// batchResolve creates the resolver of a field resolved in batches.
+func batchResolve(resolve BatchResolveFn) graphql.FieldResolveFn {
+	r := &batchResolver{
+		resolve: resolve,
+	}
+	return r.resolveField
+}
+
+// resolveField adds the source to the current batch of the field and
+// returns the thunk of its value.
+func (r *batchResolver) resolveField(p graphql.ResolveParams) (interface{}, error) {
+	var cache *batchCache
+	if p.Context != nil {
+		cache, _ = p.Context.Value(batchContextKey{}).(*batchCache)
+	}
+	if cache == nil {
+		return nil, errors.New("fields resolved in batches require the context of `NewBatchContext`")
+	}
+
+	entry := cache.load(r, p)
+	return func() (interface{}, error) {
+		return cache.get(entry)
+	}, nil
+}
+
+// load returns the entry of the source, adding it to the current batch of
+// the field (and arguments) unless it was already loaded.
+func (cache *batchCache) load(r *batchResolver, p graphql.ResolveParams) *batchEntry {
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+
+	key := batchKey{
+		resolver: r,
+		args:     argsKey(p.Args),
+	}
+	cacheable := isCacheable(p.Source)
+	if cacheable {
+		if entry, ok := cache.entries[key][p.Source]; ok {
+			return entry
+		}
+	}
+
+	b, ok := cache.batches[key]
+	if !ok {
+		b = &batch{
+			key: key,
+			ctx: context.WithValue(p.Context, batchArgsKey{}, p.Args),
+		}
+		cache.batches[key] = b
+		cache.pending = append(cache.pending, b)
+	}
+	entry := &batchEntry{
+		batch: b,
+		index: len(b.sources),
+	}
+	b.sources = append(b.sources, p.Source)
+
+	if cacheable {
+		if cache.entries[key] == nil {
+			cache.entries[key] = make(map[interface{}]*batchEntry)
+		}
+		cache.entries[key][p.Source] = entry
+	}
+	return entry
+}
+
+// get returns the value of the entry, resolving its batch when it was not
+// resolved yet. Sources loaded after that start a new batch.
+//
+// When the batches are flushed manually, the entries of batches not
+// flushed fail.
+func (cache *batchCache) get(entry *batchEntry) (interface{}, error) {
+	b := entry.batch
+	cache.mu.Lock()
+	if cache.manual {
+		flushed := b.flushed
+		cache.mu.Unlock()
+		if !flushed {
+			return nil, errors.New("the batch was not flushed")
+		}
+	} else {
+		if cache.batches[b.key] == b {
+			delete(cache.batches, b.key)
+			cache.removePending(b)
+		}
+		cache.mu.Unlock()
+		b.resolve()
+	}
+
+	if b.err != nil {
+		return nil, b.err
+	}
+	return b.values[entry.index], nil
+}
+
+// removePending removes the batch from the pending batches.
+func (cache *batchCache) removePending(b *batch) {
+	for i, pending := range cache.pending {
+		if pending == b {
+			cache.pending = append(cache.pending[:i], cache.pending[i+1:]...)
+			return
+		}
+	}
+}
+
+// argsKey returns the key of the arguments of a field in the caches. The
+// arguments are compared by their printed values (fmt sorts the keys of
+// maps).
+func argsKey(args map[string]interface{}) string {
+	if len(args) == 0 {
+		return ""
+	}
+	return fmt.Sprintf("%#v", args)
+}
+
+// isCacheable checks if the source can be a key of the cache: pointers,
+// strings, numbers and booleans.
+func isCacheable(source interface{}) bool {
+	if source == nil {
+		return false
+	}
+	switch reflect.TypeOf(source).Kind() {
+	case reflect.Ptr, reflect.String, reflect.Bool,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,278 @@
+package gqlstruct_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/graphql-go/graphql"
+	"github.com/lab259/go-graphql-struct"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+type BatchCustomer struct {
+	ID   string `graphql:"id"`
+	Name string `graphql:"name"`
+}
+
+// batchRatingsKey is the key of the context recording the calls of
+// `BatchRating.GraphqlBatchResolve`.
+type batchRatingsKey struct{}
+
+type BatchRating int
+
+func (*BatchRating) GraphqlType() graphql.Type {
+	return graphql.Int
+}
+
+func (*BatchRating) GraphqlBatchResolve(ctx context.Context, sources []interface{}) ([]interface{}, error) {
+	if calls, ok := ctx.Value(batchRatingsKey{}).(*[][]interface{}); ok {
+		*calls = append(*calls, sources)
+	}
+	r := make([]interface{}, len(sources))
+	for i, source := range sources {
+		r[i] = len(source.(*BatchOrder).ID)
+	}
+	return r, nil
+}
+
+type BatchOrder struct {
+	ID         string         `graphql:"id"`
+	CustomerID string         `graphql:"customerId"`
+	Customer   *BatchCustomer `graphql:"customer"`
+	Rating     BatchRating    `graphql:"rating"`
+}
+
+type BatchReviewsArgs struct {
+	Limit int `graphql:"limit"`
+}
+
+type BatchQuery struct {
+	Orders []*BatchOrder `graphql:"orders"`
+}
+
+var _ = Describe("Batches", func() {
+	var calls [][]string
+	var loadErr error
+	var loadMissing int
+
+	orders := []*BatchOrder{
+		{ID: "1", CustomerID: "a"},
+		{ID: "22", CustomerID: "b"},
+		{ID: "333", CustomerID: "a"},
+	}
+
+	loadCustomers := func(ctx context.Context, sources []interface{}) ([]interface{}, error) {
+		ids := make([]string, len(sources))
+		r := make([]interface{}, len(sources))
+		for i, source := range sources {
+			ids[i] = source.(*BatchOrder).ID
+			r[i] = &BatchCustomer{ID: source.(*BatchOrder).CustomerID, Name: "Customer " + source.(*BatchOrder).CustomerID}
+		}
+		calls = append(calls, ids)
+		if loadErr != nil {
+			return nil, loadErr
+		}
+		return r[:len(r)-loadMissing], nil
+	}
+
+	BeforeEach(func() {
+		calls, loadErr, loadMissing = nil, nil, 0
+	})
+
+	newSchema := func() graphql.Schema {
+		enc := gqlstruct.NewEncoder()
+		_, err := enc.Struct(BatchOrder{}, gqlstruct.WithFieldOptions("customer", gqlstruct.WithBatchResolve(loadCustomers)))
+		Expect(err).ToNot(HaveOccurred())
+		schema, err := enc.Schema(&BatchQuery{Orders: orders}, nil, nil)
+		Expect(err).ToNot(HaveOccurred())
+		return schema
+	}
+
+	doContext := func(ctx context.Context, schema graphql.Schema, query string) *graphql.Result {
+		return graphql.Do(graphql.Params{
+			Schema:        schema,
+			RequestString: query,
+			Context:       gqlstruct.NewBatchContext(ctx),
+		})
+	}
+
+	do := func(schema graphql.Schema, query string) *graphql.Result {
+		return doContext(context.Background(), schema, query)
+	}
+
+	It("should resolve the fields of all sources together", func() {
+		r := do(newSchema(), `{ orders { id customer { id name } } }`)
+		Expect(r.Errors).To(BeEmpty())
+		Expect(r.Data).To(Equal(map[string]interface{}{
+			"orders": []interface{}{
+				map[string]interface{}{"id": "1", "customer": map[string]interface{}{"id": "a", "name": "Customer a"}},
+				map[string]interface{}{"id": "22", "customer": map[string]interface{}{"id": "b", "name": "Customer b"}},
+				map[string]interface{}{"id": "333", "customer": map[string]interface{}{"id": "a", "name": "Customer a"}},
+			},
+		}))
+		Expect(calls).To(Equal([][]string{{"1", "22", "333"}}))
+	})
+
+	It("should resolve the fields nested in the batches in batches", func() {
+		var names [][]string
+		enc := gqlstruct.NewEncoder()
+		_, err := enc.Struct(BatchCustomer{}, gqlstruct.WithFieldOptions("name", gqlstruct.WithBatchResolve(func(ctx context.Context, sources []interface{}) ([]interface{}, error) {
+			ids := make([]string, len(sources))
+			r := make([]interface{}, len(sources))
+			for i, source := range sources {
+				ids[i] = source.(*BatchCustomer).ID
+				r[i] = "Batched " + ids[i]
+			}
+			names = append(names, ids)
+			return r, nil
+		})))
+		Expect(err).ToNot(HaveOccurred())
+		_, err = enc.Struct(BatchOrder{}, gqlstruct.WithFieldOptions("customer", gqlstruct.WithBatchResolve(loadCustomers)))
+		Expect(err).ToNot(HaveOccurred())
+		schema, err := enc.Schema(&BatchQuery{Orders: orders}, nil, nil)
+		Expect(err).ToNot(HaveOccurred())
+
+		// The executor of graphql-go resolves the thunks of each level of
+		// the query together, so each level is a single batch.
+		r := do(schema, `{ orders { id customer { name } } }`)
+		Expect(r.Errors).To(BeEmpty())
+		Expect(r.Data).To(Equal(map[string]interface{}{
+			"orders": []interface{}{
+				map[string]interface{}{"id": "1", "customer": map[string]interface{}{"name": "Batched a"}},
+				map[string]interface{}{"id": "22", "customer": map[string]interface{}{"name": "Batched b"}},
+				map[string]interface{}{"id": "333", "customer": map[string]interface{}{"name": "Batched a"}},
+			},
+		}))
+		Expect(calls).To(Equal([][]string{{"1", "22", "333"}}))
+		Expect(names).To(Equal([][]string{{"a", "b", "a"}}))
+	})
+
+	It("should cache the values by request", func() {
+		schema := newSchema()
+		r := do(schema, `{ orders { customer { id } } again: orders { c: customer { name } } }`)
+		Expect(r.Errors).To(BeEmpty())
+		Expect(calls).To(Equal([][]string{{"1", "22", "333"}}))
+
+		r = do(schema, `{ orders { customer { id } } }`)
+		Expect(r.Errors).To(BeEmpty())
+		Expect(calls).To(Equal([][]string{{"1", "22", "333"}, {"1", "22", "333"}}))
+	})
+
+	It("should resolve the fields of the types resolved in batches", func() {
+		var ratings [][]interface{}
+		ctx := context.WithValue(context.Background(), batchRatingsKey{}, &ratings)
+		r := doContext(ctx, newSchema(), `{ orders { rating } }`)
+		Expect(r.Errors).To(BeEmpty())
+		Expect(r.Data).To(Equal(map[string]interface{}{
+			"orders": []interface{}{
+				map[string]interface{}{"rating": 1},
+				map[string]interface{}{"rating": 2},
+				map[string]interface{}{"rating": 3},
+			},
+		}))
+		Expect(ratings).To(HaveLen(1))
+		Expect(ratings[0]).To(Equal([]interface{}{orders[0], orders[1], orders[2]}))
+	})
+
+	It("should fail all fields of the batch with its error", func() {
+		loadErr = errors.New("database unavailable")
+		r := do(newSchema(), `{ orders { id customer { id } } }`)
+		Expect(r.Errors).To(HaveLen(3))
+		for _, err := range r.Errors {
+			Expect(err.Message).To(Equal("database unavailable"))
+		}
+		Expect(r.Data.(map[string]interface{})["orders"].([]interface{})[0]).To(Equal(map[string]interface{}{
+			"id":       "1",
+			"customer": nil,
+		}))
+	})
+
+	It("should fail when the batch does not resolve all sources", func() {
+		loadMissing = 1
+		r := do(newSchema(), `{ orders { customer { id } } }`)
+		Expect(r.Errors).To(HaveLen(3))
+		Expect(r.Errors[0].Message).To(Equal("the batch resolved 2 values for 3 sources"))
+	})
+
+	It("should fail without the batch context", func() {
+		r := graphql.Do(graphql.Params{
+			Schema:        newSchema(),
+			RequestString: `{ orders { customer { id } } }`,
+		})
+		Expect(r.Errors).To(HaveLen(3))
+		Expect(r.Errors[0].Message).To(Equal("fields resolved in batches require the context of `NewBatchContext`"))
+		Expect(calls).To(BeEmpty())
+	})
+
+	It("should batch and cache the values by the arguments of the field", func() {
+		var limits []interface{}
+		enc := gqlstruct.NewEncoder()
+		_, err := enc.Struct(BatchOrder{}, gqlstruct.WithFieldOptions("customer",
+			enc.WithArgs(BatchReviewsArgs{}),
+			gqlstruct.WithBatchResolve(func(ctx context.Context, sources []interface{}) ([]interface{}, error) {
+				limit := gqlstruct.BatchArgs(ctx)["limit"]
+				limits = append(limits, limit)
+				r := make([]interface{}, len(sources))
+				for i, source := range sources {
+					r[i] = &BatchCustomer{ID: source.(*BatchOrder).CustomerID, Name: fmt.Sprint(limit)}
+				}
+				return r, nil
+			}),
+		))
+		Expect(err).ToNot(HaveOccurred())
+		schema, err := enc.Schema(&BatchQuery{Orders: orders[:1]}, nil, nil)
+		Expect(err).ToNot(HaveOccurred())
+
+		r := do(schema, `{ orders { a: customer(limit: 1) { name } b: customer(limit: 2) { name } c: customer(limit: 1) { name } } }`)
+		Expect(r.Errors).To(BeEmpty())
+		Expect(r.Data).To(Equal(map[string]interface{}{
+			"orders": []interface{}{
+				map[string]interface{}{
+					"a": map[string]interface{}{"name": "1"},
+					"b": map[string]interface{}{"name": "2"},
+					"c": map[string]interface{}{"name": "1"},
+				},
+			},
+		}))
+		Expect(limits).To(ConsistOf(1, 2))
+	})
+
+	Describe("BatchHarness", func() {
+		var field *graphql.FieldDefinition
+
+		BeforeEach(func() {
+			obj, err := gqlstruct.NewEncoder().Struct(BatchOrder{}, gqlstruct.WithFieldOptions("customer", gqlstruct.WithBatchResolve(loadCustomers)))
+			Expect(err).ToNot(HaveOccurred())
+			field = obj.Fields()["customer"]
+		})
+
+		It("should resolve the batches only when flushed", func() {
+			h := gqlstruct.NewBatchHarness(context.Background())
+			first := h.Resolve(field.Resolve, orders[0], nil)
+			second := h.Resolve(field.Resolve, orders[1], nil)
+
+			_, err := first()
+			Expect(err).To(MatchError("the batch was not flushed"))
+			Expect(calls).To(BeEmpty())
+
+			Expect(h.Flush()).To(Equal(1))
+			Expect(calls).To(Equal([][]string{{"1", "22"}}))
+			Expect(first()).To(Equal(&BatchCustomer{ID: "a", Name: "Customer a"}))
+			Expect(second()).To(Equal(&BatchCustomer{ID: "b", Name: "Customer b"}))
+		})
+
+		It("should start new batches after flushing", func() {
+			h := gqlstruct.NewBatchHarness(context.Background())
+			h.Resolve(field.Resolve, orders[0], nil)
+			Expect(h.Flush()).To(Equal(1))
+
+			h.Resolve(field.Resolve, orders[0], nil)
+			third := h.Resolve(field.Resolve, orders[2], nil)
+			Expect(h.Flush()).To(Equal(1))
+			Expect(calls).To(Equal([][]string{{"1"}, {"333"}}))
+			Expect(third()).To(Equal(&BatchCustomer{ID: "a", Name: "Customer a"}))
+			Expect(h.Flush()).To(BeZero())
+		})
+	})
+})
@@ -0,0 +1,355 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/lab259/go-graphql-struct"
+	"go/format"
+	"go/types"
+	"golang.org/x/tools/go/packages"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const graphqlPath = "github.com/graphql-go/graphql"
+
+// generator generates the code of the structs of a package.
+type generator struct {
+	pkg *packages.Package
+	// imports maps the path of the packages imported by the generated code
+	// to their names.
+	imports map[string]string
+	// queue keeps the structs that still have to be generated. The structs
+	// referenced by the fields are appended while generating.
+	queue []*types.Named
+	// queued keeps the names of the structs already queued.
+	queued map[string]bool
+	// tagKey, typePrefix and typeSuffix are the options of the encoder
+	// supported by the generator (check `gqlstruct.WithTagKey`,
+	// `gqlstruct.WithTypePrefix` and `gqlstruct.WithTypeSuffix`).
+	tagKey     string
+	typePrefix string
+	typeSuffix string
+}
+
+func newGenerator(pattern string) (*generator, error) {
+	pkgs, err := packages.Load(&packages.Config{
+		Mode: packages.NeedName | packages.NeedFiles | packages.NeedTypes,
+	}, pattern)
+	if err != nil {
+		return nil, err
+	}
+	if len(pkgs) != 1 {
+		return nil, fmt.Errorf("%d packages found for '%s', only one is expected", len(pkgs), pattern)
+	}
+	if len(pkgs[0].Errors) > 0 {
+		return nil, pkgs[0].Errors[0]
+	}
+	return &generator{
+		pkg:     pkgs[0],
+		imports: make(map[string]string),
+		queued:  make(map[string]bool),
+		tagKey:  "graphql",
+	}, nil
+}
+
+// dir returns the directory of the package.
+func (g *generator) dir() string {
+	if len(g.pkg.GoFiles) == 0 {
+		return "."
+	}
+	return filepath.Dir(g.pkg.GoFiles[0])
+}
+
+// generate returns the formatted source of the objects of the structs
+// informed, and the structs referenced by them.
+func (g *generator) generate(names []string) ([]byte, error) {
+	for _, name := range names {
+		name = strings.TrimSpace(name)
+		obj, ok := g.pkg.Types.Scope().Lookup(name).(*types.TypeName)
+		if !ok {
+			return nil, fmt.Errorf("%s: type not found in %s", name, g.pkg.PkgPath)
+		}
+		named, ok := obj.Type().(*types.Named)
+		if !ok {
+			return nil, fmt.Errorf("%s: is not a named type", name)
+		}
+		if _, ok := named.Underlying().(*types.Struct); !ok {
+			return nil, fmt.Errorf("%s: is not a struct", name)
+		}
+		g.enqueue(named)
+	}
+
+	objects := make(map[string][]byte)
+	for i := 0; i < len(g.queue); i++ {
+		named := g.queue[i]
+		var buf bytes.Buffer
+		if err := g.writeObject(&buf, named); err != nil {
+			return nil, err
+		}
+		objects[named.Obj().Name()] = buf.Bytes()
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("// Code generated by gqlstruct-gen; DO NOT EDIT.\n\n")
+	fmt.Fprintf(&buf, "package %s\n\n", g.pkg.Name)
+
+	paths := []string{"sync", graphqlPath}
+	for path := range g.imports {
+		if path != graphqlPath {
+			paths = append(paths, path)
+		}
+	}
+	sort.Strings(paths)
+	buf.WriteString("import (\n")
+	for _, path := range paths {
+		fmt.Fprintf(&buf, "\t%s\n", strconv.Quote(path))
+	}
+	buf.WriteString(")\n")
+
+	structNames := make([]string, 0, len(objects))
+	for name := range objects {
+		structNames = append(structNames, name)
+	}
+	sort.Strings(structNames)
+	for _, name := range structNames {
+		buf.WriteString("\n")
+		buf.Write(objects[name])
+	}
+
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("formatting the generated code: %s", err)
+	}
+	return src, nil
+}
+
+func (g *generator) enqueue(named *types.Named) {
+	if g.queued[named.Obj().Name()] {
+		return
+	}
+	g.queued[named.Obj().Name()] = true
+	g.queue = append(g.queue, named)
+}
+
+// qualifier names the packages in the generated code, recording the
+// imports needed.
+func (g *generator) qualifier(pkg *types.Package) string {
+	if pkg == g.pkg.Types {
+		return ""
+	}
+	g.imports[pkg.Path()] = pkg.Name()
+	return pkg.Name()
+}
+
+func (g *generator) typeString(t types.Type) string {
+	return types.TypeString(t, g.qualifier)
+}
+
+// writeObject writes the function that builds the `*graphql.Object` of the
+// struct informed.
+func (g *generator) writeObject(buf *bytes.Buffer, named *types.Named) error {
+	name := named.Obj().Name()
+	st := named.Underlying().(*types.Struct)
+
+	if hasMethod(named, "GraphqlMethods") {
+		return fmt.Errorf("%s: methods are not supported", name)
+	}
+	if hasMethod(named, "GraphqlFieldOptions") {
+		return fmt.Errorf("%s: field options are not supported", name)
+	}
+
+	var fields bytes.Buffer
+	tagged := false
+	for i := 0; i < st.NumFields(); i++ {
+		field := st.Field(i)
+		tag, ok := reflect.StructTag(st.Tag(i)).Lookup(g.tagKey)
+		if !ok || tag == "-" {
+			// If the field is not tagged, ignore it.
+			continue
+		}
+		tagged = true
+
+		info, err := gqlstruct.ParseTag(tag)
+		if err != nil {
+			return fmt.Errorf("%s.%s: invalid tag `%s`: %s", name, field.Name(), tag, err)
+		}
+		if info.Interface {
+			return fmt.Errorf("%s.%s: interfaces are not supported", name, field.Name())
+		}
+		if info.HasDefault {
+			return fmt.Errorf("%s.%s: default values are only supported by arguments and input fields", name, field.Name())
+		}
+		if info.Connection {
+			return fmt.Errorf("%s.%s: connections are not supported", name, field.Name())
+		}
+		if _, ok := reflect.StructTag(st.Tag(i)).Lookup("auth"); ok {
+			return fmt.Errorf("%s.%s: policies are not supported", name, field.Name())
+		}
+		if !types.IsInterface(field.Type()) && hasMethod(field.Type(), "GraphqlBatchResolve") {
+			return fmt.Errorf("%s.%s: batch resolvers are not supported", name, field.Name())
+		}
+
+		fieldType, err := g.typeExpr(field.Type())
+		if err != nil {
+			return fmt.Errorf("%s.%s: %s", name, field.Name(), err)
+		}
+		if info.NonNull {
+			fieldType = "graphql.NewNonNull(" + fieldType + ")"
+		}
+
+		fmt.Fprintf(&fields, "%s: &graphql.Field{\n", strconv.Quote(info.Name))
+		fmt.Fprintf(&fields, "Type: %s,\n", fieldType)
+		if info.Description != "" {
+			fmt.Fprintf(&fields, "Description: %s,\n", strconv.Quote(info.Description))
+		}
+		if info.DeprecationReason != "" {
+			fmt.Fprintf(&fields, "DeprecationReason: %s,\n", strconv.Quote(info.DeprecationReason))
+		}
+		fmt.Fprintf(&fields, "Resolve: %s,\n", g.resolveExpr(named, field))
+		fields.WriteString("},\n")
+	}
+	if !tagged {
+		// Untagged fields are described by the encoder only through its
+		// options, which cannot be known here.
+		return fmt.Errorf("%s: no fields tagged by `%s`, untagged fields are not supported", name, g.tagKey)
+	}
+
+	fmt.Fprintf(buf, `var (
+	graphql%[1]sObject     *graphql.Object
+	graphql%[1]sObjectOnce sync.Once
+)
+
+// %[1]sGraphqlObject returns the `+"`*graphql.Object`"+` that describes %[1]s.
+func %[1]sGraphqlObject() *graphql.Object {
+	graphql%[1]sObjectOnce.Do(func() {
+		graphql%[1]sObject = graphql.NewObject(graphql.ObjectConfig{
+			Name: %[2]s,
+			Fields: graphql.FieldsThunk(func() graphql.Fields {
+				return graphql.Fields{
+%[3]s
+				}
+			}),
+		})
+	})
+	return graphql%[1]sObject
+}
+`, name, strconv.Quote(g.typePrefix+name+g.typeSuffix), fields.String())
+	return nil
+}
+
+// typeExpr returns the expression of the `graphql.Type` of the type
+// informed, following the rules of the encoder.
+func (g *generator) typeExpr(t types.Type) (string, error) {
+	if expr, ok := g.typedExpr(t); ok {
+		return expr, nil
+	}
+
+	if ptr, ok := t.(*types.Pointer); ok {
+		t = ptr.Elem()
+	}
+
+	if hasMethod(t, "GraphqlEnumValues") {
+		return "", fmt.Errorf("enums are not supported")
+	}
+
+	if named, ok := t.(*types.Named); ok && named.Obj().Pkg() != nil &&
+		named.Obj().Pkg().Path() == "time" && named.Obj().Name() == "Time" {
+		return "graphql.DateTime", nil
+	}
+
+	switch u := t.Underlying().(type) {
+	case *types.Basic:
+		switch {
+		case u.Info()&types.IsBoolean != 0:
+			return "graphql.Boolean", nil
+		case u.Info()&types.IsString != 0:
+			return "graphql.String", nil
+		case u.Info()&types.IsInteger != 0 && u.Kind() != types.Uintptr:
+			return "graphql.Int", nil
+		case u.Info()&(types.IsFloat|types.IsComplex) != 0:
+			return "graphql.Float", nil
+		}
+	case *types.Struct:
+		named, ok := t.(*types.Named)
+		if !ok {
+			return "", fmt.Errorf("anonymous structs are not supported")
+		}
+		if named.Obj().Pkg() != g.pkg.Types {
+			return "", fmt.Errorf("%s: structs from other packages are not supported", g.typeString(named))
+		}
+		g.enqueue(named)
+		return named.Obj().Name() + "GraphqlObject()", nil
+	case *types.Slice:
+		elem, err := g.typeExpr(u.Elem())
+		if err != nil {
+			return "", err
+		}
+		return "graphql.NewList(" + elem + ")", nil
+	case *types.Array:
+		elem, err := g.typeExpr(u.Elem())
+		if err != nil {
+			return "", err
+		}
+		return "graphql.NewList(" + elem + ")", nil
+	}
+	return "", fmt.Errorf("the type %s is not supported", g.typeString(t))
+}
+
+// typedExpr returns the expression that calls the `GraphqlType` method of
+// the types that implement `gqlstruct.GraphqlTyped`.
+func (g *generator) typedExpr(t types.Type) (string, bool) {
+	if ptr, ok := t.(*types.Pointer); ok {
+		t = ptr.Elem()
+	}
+	if types.IsInterface(t) || !hasMethod(t, "GraphqlType") {
+		return "", false
+	}
+	return "new(" + g.typeString(t) + ").GraphqlType()", true
+}
+
+// resolveExpr returns the expression of the resolver of the field. Types
+// implementing `gqlstruct.GraphqlResolver` provide their resolvers, as
+// `StructOf` does. Otherwise, a typed resolver reads the field from the
+// source.
+func (g *generator) resolveExpr(named *types.Named, field *types.Var) string {
+	t := field.Type()
+	if !types.IsInterface(t) && hasMethod(t, "GraphqlResolve") {
+		if _, ok := t.(*types.Pointer); ok {
+			return "(" + g.typeString(t) + ")(nil).GraphqlResolve"
+		}
+		if _, ok := t.Underlying().(*types.Struct); ok || methodSetHas(t, "GraphqlResolve") {
+			return "new(" + g.typeString(t) + ").GraphqlResolve"
+		}
+	}
+
+	name := g.typeString(named)
+	return fmt.Sprintf(`func(p graphql.ResolveParams) (interface{}, error) {
+		switch src := p.Source.(type) {
+		case *%[1]s:
+			if src == nil {
+				return nil, nil
+			}
+			return src.%[2]s, nil
+		case %[1]s:
+			return src.%[2]s, nil
+		}
+		return graphql.DefaultResolveFn(p)
+	}`, name, field.Name())
+}
+
+// hasMethod checks if the type, or a pointer to it, has the method informed.
+func hasMethod(t types.Type, name string) bool {
+	if _, ok := t.(*types.Pointer); !ok {
+		t = types.NewPointer(t)
+	}
+	return methodSetHas(t, name)
+}
+
+// methodSetHas checks if the method set of the type has the method informed.
+func methodSetHas(t types.Type, name string) bool {
+	return types.NewMethodSet(t).Lookup(nil, name) != nil
+}
@@ -0,0 +1,73 @@
+package main
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"io/ioutil"
+	"path/filepath"
+)
+
+var _ = Describe("Generator", func() {
+	It("should generate the committed code of the models", func() {
+		g, err := newGenerator("./internal/models")
+		Expect(err).ToNot(HaveOccurred())
+
+		src, err := g.generate([]string{"User"})
+		Expect(err).ToNot(HaveOccurred())
+
+		committed, err := ioutil.ReadFile(filepath.Join(g.dir(), "models_gqlstruct.go"))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(string(src)).To(Equal(string(committed)), "run `go generate ./cmd/gqlstruct-gen/internal/models`")
+	})
+
+	It("should fail with the structs that are not supported", func() {
+		errs := map[string]string{
+			"WithInterface":     "WithInterface.Node: interfaces are not supported",
+			"WithAnonymous":     "WithAnonymous.Address: anonymous structs are not supported",
+			"WithMap":           "WithMap.Attributes: the type map[string]string is not supported",
+			"WithInvalidTag":    "WithInvalidTag.Name: invalid tag `name,unknown`: unknown option 'unknown'",
+			"WithEnum":          "WithEnum.Status: enums are not supported",
+			"WithConnection":    "WithConnection.Tags: connections are not supported",
+			"WithPolicy":        "WithPolicy.Email: policies are not supported",
+			"WithBatchResolver": "WithBatchResolver.Customer: batch resolvers are not supported",
+			"WithMethods":       "WithMethods: methods are not supported",
+			"WithFieldOptions":  "WithFieldOptions: field options are not supported",
+			"WithUntagged":      "WithUntagged: no fields tagged by `graphql`, untagged fields are not supported",
+			"NotStruct":         "NotStruct: is not a struct",
+		}
+		for name, msg := range errs {
+			g, err := newGenerator("./testdata/unsupported")
+			Expect(err).ToNot(HaveOccurred())
+
+			_, err = g.generate([]string{name})
+			Expect(err).To(HaveOccurred(), name)
+			Expect(err.Error()).To(Equal(msg))
+		}
+	})
+
+	It("should generate with the tag key and the type prefix and suffix", func() {
+		g, err := newGenerator("./testdata/options")
+		Expect(err).ToNot(HaveOccurred())
+		g.tagKey, g.typePrefix, g.typeSuffix = "gql", "Api", "V2"
+
+		src, err := g.generate([]string{"Account"})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(string(src)).To(ContainSubstring(`Name: "ApiAccountV2"`))
+		Expect(string(src)).To(ContainSubstring(`"name": &graphql.Field{`))
+		Expect(string(src)).ToNot(ContainSubstring(`"email"`))
+		Expect(string(src)).ToNot(ContainSubstring(`"-"`))
+	})
+
+	It("should fail with the structs not found", func() {
+		g, err := newGenerator("./testdata/unsupported")
+		Expect(err).ToNot(HaveOccurred())
+
+		_, err = g.generate([]string{"Missing"})
+		Expect(err).To(MatchError("Missing: type not found in " + g.pkg.PkgPath))
+	})
+
+	It("should fail when the package cannot be loaded", func() {
+		_, err := newGenerator("./testdata/missing")
+		Expect(err).To(HaveOccurred())
+	})
+})
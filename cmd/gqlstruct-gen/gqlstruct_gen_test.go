@@ -0,0 +1,15 @@
+package main
+
+import (
+	"github.com/jamillosantos/macchiato"
+	"github.com/onsi/ginkgo"
+	"github.com/onsi/gomega"
+	"log"
+	"testing"
+)
+
+func TestGqlStructGen(t *testing.T) {
+	log.SetOutput(ginkgo.GinkgoWriter)
+	gomega.RegisterFailHandler(ginkgo.Fail)
+	macchiato.RunSpecs(t, "gqlstruct-gen: Code Generator Test Suite")
+}
@@ -0,0 +1,52 @@
+// Package models has the structs used to verify that the code generated by
+// gqlstruct-gen describes the same objects as `gqlstruct.StructOf`.
+package models
+
+//go:generate go run github.com/lab259/go-graphql-struct/cmd/gqlstruct-gen -type User
+
+import (
+	"github.com/graphql-go/graphql"
+	"time"
+)
+
+// Cents is a custom scalar provided by `GraphqlType`.
+type Cents int64
+
+func (Cents) GraphqlType() graphql.Type {
+	return graphql.String
+}
+
+// Avatar provides its own resolver.
+type Avatar string
+
+func (Avatar) GraphqlResolve(p graphql.ResolveParams) (interface{}, error) {
+	return "https://example.com/avatar.png", nil
+}
+
+type User struct {
+	ID        string    `graphql:"!id"`
+	FullName  string    `graphql:"name,desc=The full name of the user."`
+	Login     string    `graphql:"login,deprecated=Use name."`
+	Age       int       `graphql:"age"`
+	Admin     bool      `graphql:"admin"`
+	Score     float64   `graphql:"score"`
+	CreatedAt time.Time `graphql:"createdAt"`
+	Tags      []string  `graphql:"tags"`
+	Avatar    Avatar    `graphql:"avatar"`
+	Friends   []*User   `graphql:"friends"`
+	Orders    []Order   `graphql:"!orders"`
+	password  string
+}
+
+type Order struct {
+	ID      string   `graphql:"!id"`
+	Total   Cents    `graphql:"total"`
+	Items   [][]int  `graphql:"items"`
+	Buyer   *User    `graphql:"buyer"`
+	Product *Product `graphql:"product"`
+}
+
+type Product struct {
+	Name  string `graphql:"!name"`
+	Price *Cents `graphql:"price"`
+}
@@ -0,0 +1,326 @@
+// Code generated by gqlstruct-gen; DO NOT EDIT.
+
+package models
+
+import (
+	"github.com/graphql-go/graphql"
+	"sync"
+)
+
+var (
+	graphqlOrderObject     *graphql.Object
+	graphqlOrderObjectOnce sync.Once
+)
+
+// OrderGraphqlObject returns the `*graphql.Object` that describes Order.
+func OrderGraphqlObject() *graphql.Object {
+	graphqlOrderObjectOnce.Do(func() {
+		graphqlOrderObject = graphql.NewObject(graphql.ObjectConfig{
+			Name: "Order",
+			Fields: graphql.FieldsThunk(func() graphql.Fields {
+				return graphql.Fields{
+					"id": &graphql.Field{
+						Type: graphql.NewNonNull(graphql.String),
+						Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+							switch src := p.Source.(type) {
+							case *Order:
+								if src == nil {
+									return nil, nil
+								}
+								return src.ID, nil
+							case Order:
+								return src.ID, nil
+							}
+							return graphql.DefaultResolveFn(p)
+						},
+					},
+					"total": &graphql.Field{
+						Type: new(Cents).GraphqlType(),
+						Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+							switch src := p.Source.(type) {
+							case *Order:
+								if src == nil {
+									return nil, nil
+								}
+								return src.Total, nil
+							case Order:
+								return src.Total, nil
+							}
+							return graphql.DefaultResolveFn(p)
+						},
+					},
+					"items": &graphql.Field{
+						Type: graphql.NewList(graphql.NewList(graphql.Int)),
+						Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+							switch src := p.Source.(type) {
+							case *Order:
+								if src == nil {
+									return nil, nil
+								}
+								return src.Items, nil
+							case Order:
+								return src.Items, nil
+							}
+							return graphql.DefaultResolveFn(p)
+						},
+					},
+					"buyer": &graphql.Field{
+						Type: UserGraphqlObject(),
+						Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+							switch src := p.Source.(type) {
+							case *Order:
+								if src == nil {
+									return nil, nil
+								}
+								return src.Buyer, nil
+							case Order:
+								return src.Buyer, nil
+							}
+							return graphql.DefaultResolveFn(p)
+						},
+					},
+					"product": &graphql.Field{
+						Type: ProductGraphqlObject(),
+						Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+							switch src := p.Source.(type) {
+							case *Order:
+								if src == nil {
+									return nil, nil
+								}
+								return src.Product, nil
+							case Order:
+								return src.Product, nil
+							}
+							return graphql.DefaultResolveFn(p)
+						},
+					},
+				}
+			}),
+		})
+	})
+	return graphqlOrderObject
+}
+
+var (
+	graphqlProductObject     *graphql.Object
+	graphqlProductObjectOnce sync.Once
+)
+
+// ProductGraphqlObject returns the `*graphql.Object` that describes Product.
+func ProductGraphqlObject() *graphql.Object {
+	graphqlProductObjectOnce.Do(func() {
+		graphqlProductObject = graphql.NewObject(graphql.ObjectConfig{
+			Name: "Product",
+			Fields: graphql.FieldsThunk(func() graphql.Fields {
+				return graphql.Fields{
+					"name": &graphql.Field{
+						Type: graphql.NewNonNull(graphql.String),
+						Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+							switch src := p.Source.(type) {
+							case *Product:
+								if src == nil {
+									return nil, nil
+								}
+								return src.Name, nil
+							case Product:
+								return src.Name, nil
+							}
+							return graphql.DefaultResolveFn(p)
+						},
+					},
+					"price": &graphql.Field{
+						Type: new(Cents).GraphqlType(),
+						Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+							switch src := p.Source.(type) {
+							case *Product:
+								if src == nil {
+									return nil, nil
+								}
+								return src.Price, nil
+							case Product:
+								return src.Price, nil
+							}
+							return graphql.DefaultResolveFn(p)
+						},
+					},
+				}
+			}),
+		})
+	})
+	return graphqlProductObject
+}
+
+var (
+	graphqlUserObject     *graphql.Object
+	graphqlUserObjectOnce sync.Once
+)
+
+// UserGraphqlObject returns the `*graphql.Object` that describes User.
+func UserGraphqlObject() *graphql.Object {
+	graphqlUserObjectOnce.Do(func() {
+		graphqlUserObject = graphql.NewObject(graphql.ObjectConfig{
+			Name: "User",
+			Fields: graphql.FieldsThunk(func() graphql.Fields {
+				return graphql.Fields{
+					"id": &graphql.Field{
+						Type: graphql.NewNonNull(graphql.String),
+						Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+							switch src := p.Source.(type) {
+							case *User:
+								if src == nil {
+									return nil, nil
+								}
+								return src.ID, nil
+							case User:
+								return src.ID, nil
+							}
+							return graphql.DefaultResolveFn(p)
+						},
+					},
+					"name": &graphql.Field{
+						Type:        graphql.String,
+						Description: "The full name of the user.",
+						Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+							switch src := p.Source.(type) {
+							case *User:
+								if src == nil {
+									return nil, nil
+								}
+								return src.FullName, nil
+							case User:
+								return src.FullName, nil
+							}
+							return graphql.DefaultResolveFn(p)
+						},
+					},
+					"login": &graphql.Field{
+						Type:              graphql.String,
+						DeprecationReason: "Use name.",
+						Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+							switch src := p.Source.(type) {
+							case *User:
+								if src == nil {
+									return nil, nil
+								}
+								return src.Login, nil
+							case User:
+								return src.Login, nil
+							}
+							return graphql.DefaultResolveFn(p)
+						},
+					},
+					"age": &graphql.Field{
+						Type: graphql.Int,
+						Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+							switch src := p.Source.(type) {
+							case *User:
+								if src == nil {
+									return nil, nil
+								}
+								return src.Age, nil
+							case User:
+								return src.Age, nil
+							}
+							return graphql.DefaultResolveFn(p)
+						},
+					},
+					"admin": &graphql.Field{
+						Type: graphql.Boolean,
+						Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+							switch src := p.Source.(type) {
+							case *User:
+								if src == nil {
+									return nil, nil
+								}
+								return src.Admin, nil
+							case User:
+								return src.Admin, nil
+							}
+							return graphql.DefaultResolveFn(p)
+						},
+					},
+					"score": &graphql.Field{
+						Type: graphql.Float,
+						Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+							switch src := p.Source.(type) {
+							case *User:
+								if src == nil {
+									return nil, nil
+								}
+								return src.Score, nil
+							case User:
+								return src.Score, nil
+							}
+							return graphql.DefaultResolveFn(p)
+						},
+					},
+					"createdAt": &graphql.Field{
+						Type: graphql.DateTime,
+						Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+							switch src := p.Source.(type) {
+							case *User:
+								if src == nil {
+									return nil, nil
+								}
+								return src.CreatedAt, nil
+							case User:
+								return src.CreatedAt, nil
+							}
+							return graphql.DefaultResolveFn(p)
+						},
+					},
+					"tags": &graphql.Field{
+						Type: graphql.NewList(graphql.String),
+						Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+							switch src := p.Source.(type) {
+							case *User:
+								if src == nil {
+									return nil, nil
+								}
+								return src.Tags, nil
+							case User:
+								return src.Tags, nil
+							}
+							return graphql.DefaultResolveFn(p)
+						},
+					},
+					"avatar": &graphql.Field{
+						Type:    graphql.String,
+						Resolve: new(Avatar).GraphqlResolve,
+					},
+					"friends": &graphql.Field{
+						Type: graphql.NewList(UserGraphqlObject()),
+						Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+							switch src := p.Source.(type) {
+							case *User:
+								if src == nil {
+									return nil, nil
+								}
+								return src.Friends, nil
+							case User:
+								return src.Friends, nil
+							}
+							return graphql.DefaultResolveFn(p)
+						},
+					},
+					"orders": &graphql.Field{
+						Type: graphql.NewNonNull(graphql.NewList(OrderGraphqlObject())),
+						Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+							switch src := p.Source.(type) {
+							case *User:
+								if src == nil {
+									return nil, nil
+								}
+								return src.Orders, nil
+							case User:
+								return src.Orders, nil
+							}
+							return graphql.DefaultResolveFn(p)
+						},
+					},
+				}
+			}),
+		})
+	})
+	return graphqlUserObject
+}
@@ -0,0 +1,98 @@
+package models_test
+
+import (
+	"encoding/json"
+	"github.com/graphql-go/graphql"
+	"github.com/lab259/go-graphql-struct"
+	"github.com/lab259/go-graphql-struct/cmd/gqlstruct-gen/internal/models"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"time"
+)
+
+// newSchema builds a schema that exposes the user informed through the
+// object of the User struct.
+func newSchema(userObject *graphql.Object, user *models.User) graphql.Schema {
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Query",
+			Fields: graphql.Fields{
+				"user": &graphql.Field{
+					Type: userObject,
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						return user, nil
+					},
+				},
+			},
+		}),
+	})
+	Expect(err).ToNot(HaveOccurred())
+	return schema
+}
+
+var _ = Describe("Generated code", func() {
+	user := &models.User{
+		ID:        "1",
+		FullName:  "Snake Eyes",
+		Login:     "snake",
+		Age:       35,
+		Admin:     true,
+		Score:     9.5,
+		CreatedAt: time.Date(2018, 10, 1, 12, 0, 0, 0, time.UTC),
+		Tags:      []string{"ninja"},
+		Orders: []models.Order{
+			{
+				ID:    "10",
+				Total: 1999,
+				Items: [][]int{{1, 2}, {3}},
+				Product: &models.Product{
+					Name: "Sword",
+				},
+			},
+		},
+	}
+	user.Friends = []*models.User{user}
+	user.Orders[0].Buyer = user
+
+	It("should describe the same objects as StructOf", func() {
+		obj, err := gqlstruct.NewEncoder().Struct(&models.User{})
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(gqlstruct.PrintSchema(newSchema(models.UserGraphqlObject(), user))).To(Equal(gqlstruct.PrintSchema(newSchema(obj, user))))
+	})
+
+	It("should resolve the same values as StructOf", func() {
+		obj, err := gqlstruct.NewEncoder().Struct(&models.User{})
+		Expect(err).ToNot(HaveOccurred())
+
+		query := `{
+			user {
+				id name login age admin score createdAt tags avatar
+				friends { id }
+				orders {
+					id total items
+					buyer { id }
+					product { name price }
+				}
+			}
+		}`
+
+		generated := graphql.Do(graphql.Params{
+			Schema:        newSchema(models.UserGraphqlObject(), user),
+			RequestString: query,
+		})
+		Expect(generated.Errors).To(BeEmpty())
+		reflected := graphql.Do(graphql.Params{
+			Schema:        newSchema(obj, user),
+			RequestString: query,
+		})
+		Expect(reflected.Errors).To(BeEmpty())
+
+		generatedJSON, err := json.Marshal(generated.Data)
+		Expect(err).ToNot(HaveOccurred())
+		reflectedJSON, err := json.Marshal(reflected.Data)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(generatedJSON).To(MatchJSON(reflectedJSON))
+		Expect(generatedJSON).To(ContainSubstring(`"name":"Sword"`))
+	})
+})
@@ -0,0 +1,15 @@
+package models_test
+
+import (
+	"github.com/jamillosantos/macchiato"
+	"github.com/onsi/ginkgo"
+	"github.com/onsi/gomega"
+	"log"
+	"testing"
+)
+
+func TestModels(t *testing.T) {
+	log.SetOutput(ginkgo.GinkgoWriter)
+	gomega.RegisterFailHandler(ginkgo.Fail)
+	macchiato.RunSpecs(t, "gqlstruct-gen: Generated Models Test Suite")
+}
@@ -0,0 +1,92 @@
+// Command gqlstruct-gen generates the Go code that builds the
+// `*graphql.Object`s of tagged structs, without reflection.
+//
+// The structs are described the same way `gqlstruct.StructOf` does, but the
+// objects are built by static code and their fields are resolved by typed
+// resolvers:
+//
+// ```
+// //go:generate gqlstruct-gen -type User,Order
+// ```
+//
+// For each struct a `<Struct>GraphqlObject() *graphql.Object` function is
+// generated. The structs referenced by the fields of the structs informed
+// are generated too.
+//
+// The generated objects match the objects of an encoder created with the
+// equivalent options only: the tag key, the type prefix and the type suffix
+// are given by the -tag, -prefix and -suffix flags. Untagged fields (as
+// described by `WithUntaggedFields`, `WithJSONFallback` and
+// `WithNamingStrategy`) are not supported, and structs without tagged
+// fields fail.
+//
+// The generator does not implement the features that depend on the
+// encoder at runtime: interfaces, unions, enums, methods, field options,
+// default values of fields, connections, policies and batch resolvers.
+// Structs using them fail with an error naming the feature, and should be
+// built by the encoder instead.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+var (
+	typeNames = flag.String("type", "", "comma separated list of the struct names; required")
+	output    = flag.String("output", "", "output file name; default <package>_gqlstruct.go")
+	tagKey    = flag.String("tag", "graphql", "key of the struct tags that describe the fields")
+	prefix    = flag.String("prefix", "", "prefix of the names of the objects")
+	suffix    = flag.String("suffix", "", "suffix of the names of the objects")
+)
+
+func usage() {
+	fmt.Fprintf(os.Stderr, "Usage of gqlstruct-gen:\n")
+	fmt.Fprintf(os.Stderr, "\tgqlstruct-gen -type T[,T...] [-output file] [-tag key] [-prefix prefix] [-suffix suffix] [package]\n")
+	flag.PrintDefaults()
+}
+
+func main() {
+	flag.Usage = usage
+	flag.Parse()
+	if *typeNames == "" {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	pattern := "."
+	if flag.NArg() > 0 {
+		pattern = flag.Arg(0)
+	}
+
+	g, err := newGenerator(pattern)
+	if err != nil {
+		fail(err)
+	}
+	if *tagKey != "" {
+		g.tagKey = *tagKey
+	}
+	g.typePrefix, g.typeSuffix = *prefix, *suffix
+
+	src, err := g.generate(strings.Split(*typeNames, ","))
+	if err != nil {
+		fail(err)
+	}
+
+	filename := *output
+	if filename == "" {
+		filename = filepath.Join(g.dir(), g.pkg.Name+"_gqlstruct.go")
+	}
+	if err := ioutil.WriteFile(filename, src, 0644); err != nil {
+		fail(err)
+	}
+}
+
+func fail(err error) {
+	fmt.Fprintf(os.Stderr, "gqlstruct-gen: %s\n", err)
+	os.Exit(1)
+}
@@ -0,0 +1,7 @@
+package options
+
+type Account struct {
+	Name    string `gql:"name"`
+	Email   string `graphql:"email"`
+	Ignored string `gql:"-"`
+}
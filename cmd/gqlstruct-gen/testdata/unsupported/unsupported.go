@@ -0,0 +1,74 @@
+package unsupported
+
+import (
+	"context"
+	"github.com/lab259/go-graphql-struct"
+)
+
+type Node struct {
+	ID string `graphql:"!id"`
+}
+
+type WithInterface struct {
+	Node `graphql:",interface"`
+}
+
+type WithAnonymous struct {
+	Address struct {
+		Street string `graphql:"street"`
+	} `graphql:"address"`
+}
+
+type WithMap struct {
+	Attributes map[string]string `graphql:"attributes"`
+}
+
+type WithInvalidTag struct {
+	Name string `graphql:"name,unknown"`
+}
+
+type Status int
+
+func (Status) GraphqlEnumValues() []interface{} {
+	return nil
+}
+
+type WithEnum struct {
+	Status Status `graphql:"status"`
+}
+
+type WithConnection struct {
+	Tags []string `graphql:"tags,connection"`
+}
+
+type WithPolicy struct {
+	Email string `graphql:"email" auth:"admin"`
+}
+
+type BatchCustomer struct{}
+
+func (*BatchCustomer) GraphqlBatchResolve(ctx context.Context, sources []interface{}) ([]interface{}, error) {
+	return nil, nil
+}
+
+type WithBatchResolver struct {
+	Customer BatchCustomer `graphql:"customer"`
+}
+
+type WithMethods struct{}
+
+func (WithMethods) GraphqlMethods() map[string]string {
+	return nil
+}
+
+type WithFieldOptions struct{}
+
+func (WithFieldOptions) GraphqlFieldOptions() map[string][]gqlstruct.FieldOption {
+	return nil
+}
+
+type NotStruct string
+
+type WithUntagged struct {
+	Name string `json:"name"`
+}
@@ -0,0 +1,15 @@
+package main
+
+import (
+	"github.com/jamillosantos/macchiato"
+	"github.com/onsi/ginkgo"
+	"github.com/onsi/gomega"
+	"log"
+	"testing"
+)
+
+func TestGqlStructImport(t *testing.T) {
+	log.SetOutput(ginkgo.GinkgoWriter)
+	gomega.RegisterFailHandler(ginkgo.Fail)
+	macchiato.RunSpecs(t, "gqlstruct-import: SDL Importer Test Suite")
+}
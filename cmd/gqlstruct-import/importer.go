@@ -0,0 +1,905 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/language/ast"
+	"github.com/graphql-go/graphql/language/parser"
+	"github.com/graphql-go/graphql/language/printer"
+	"go/format"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+const (
+	graphqlPath    = "github.com/graphql-go/graphql"
+	gqlstructPath  = "github.com/lab259/go-graphql-struct"
+	inputSuffix    = "Input"
+	encoderMethods = `interface {
+	RegisterEnum(obj interface{}, values []gqlstruct.EnumValue, options ...gqlstruct.ObjectOption) (*graphql.Enum, error)
+	Interface(obj interface{}, options ...gqlstruct.ObjectOption) (*graphql.Interface, error)
+	RegisterUnion(iface interface{}, members []interface{}, options ...gqlstruct.ObjectOption) (*graphql.Union, error)
+	Struct(obj interface{}, options ...gqlstruct.StructOption) (*graphql.Object, error)
+	InputObject(obj interface{}, options ...gqlstruct.ObjectOption) (*graphql.InputObject, error)
+}`
+)
+
+// builtinTypes maps the scalars known by the encoder to their Go types.
+var builtinTypes = map[string]string{
+	"String":   "string",
+	"Int":      "int",
+	"Float":    "float64",
+	"Boolean":  "bool",
+	"ID":       "ID",
+	"DateTime": "time.Time",
+}
+
+// initialisms are kept in upper case in the Go names.
+var initialisms = map[string]bool{
+	"API":  true,
+	"HTML": true,
+	"HTTP": true,
+	"ID":   true,
+	"IP":   true,
+	"JSON": true,
+	"SQL":  true,
+	"URI":  true,
+	"URL":  true,
+	"UUID": true,
+}
+
+// importer generates the Go code of the types of a SDL document.
+type importer struct {
+	pkgName  string
+	filename string
+	// defs are the type definitions, in the order of the document.
+	defs []ast.Node
+	// types indexes the definitions by name.
+	types map[string]ast.Node
+	// unions maps the objects to the unions they are members of.
+	unions map[string][]string
+	// imports are the packages used by the generated code.
+	imports map[string]bool
+	// usesID is set when the ID scalar is used, so its stub is generated.
+	usesID   bool
+	warnings []string
+}
+
+func newImporter(pkgName, filename, sdl string) (*importer, error) {
+	doc, err := parser.Parse(parser.ParseParams{
+		Source: sdl,
+		Options: parser.ParseOptions{
+			NoLocation: true,
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	imp := &importer{
+		pkgName:  pkgName,
+		filename: filepath.Base(filename),
+		types:    make(map[string]ast.Node),
+		unions:   make(map[string][]string),
+		imports: map[string]bool{
+			graphqlPath:   true,
+			gqlstructPath: true,
+		},
+	}
+	for _, def := range doc.Definitions {
+		var name string
+		switch def := def.(type) {
+		case *ast.ScalarDefinition:
+			name = def.Name.Value
+		case *ast.ObjectDefinition:
+			name = def.Name.Value
+		case *ast.InterfaceDefinition:
+			name = def.Name.Value
+		case *ast.UnionDefinition:
+			name = def.Name.Value
+			for _, member := range def.Types {
+				imp.unions[member.Name.Value] = append(imp.unions[member.Name.Value], name)
+			}
+		case *ast.EnumDefinition:
+			name = def.Name.Value
+		case *ast.InputObjectDefinition:
+			name = def.Name.Value
+		case *ast.SchemaDefinition:
+			// The roots are the structs passed to `Schema`.
+			continue
+		default:
+			imp.warn("%s definitions are ignored", def.GetKind())
+			continue
+		}
+		if _, ok := imp.types[name]; ok {
+			return nil, fmt.Errorf("%s: defined more than once", name)
+		}
+		imp.types[name] = def
+		imp.defs = append(imp.defs, def)
+	}
+
+	for member, unions := range imp.unions {
+		if _, ok := imp.types[member].(*ast.ObjectDefinition); !ok {
+			return nil, fmt.Errorf("%s: the members of unions must be objects, %s is not", unions[0], member)
+		}
+	}
+	return imp, nil
+}
+
+func (imp *importer) warn(format string, args ...interface{}) {
+	imp.warnings = append(imp.warnings, fmt.Sprintf(format, args...))
+}
+
+// generate returns the formatted Go code of the types.
+func (imp *importer) generate() ([]byte, error) {
+	var body bytes.Buffer
+	for _, def := range imp.defs {
+		var err error
+		switch def := def.(type) {
+		case *ast.ScalarDefinition:
+			err = imp.writeScalar(&body, def)
+		case *ast.EnumDefinition:
+			err = imp.writeEnum(&body, def)
+		case *ast.InterfaceDefinition:
+			err = imp.writeInterface(&body, def)
+		case *ast.UnionDefinition:
+			err = imp.writeUnion(&body, def)
+		case *ast.ObjectDefinition:
+			err = imp.writeObject(&body, def)
+		case *ast.InputObjectDefinition:
+			err = imp.writeInput(&body, def)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	imp.writeRegisterTypes(&body)
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "// Generated by gqlstruct-import from %s.\n", imp.filename)
+	buf.WriteString("//\n// The stubs of the methods and scalars have to be implemented.\n\n")
+	fmt.Fprintf(&buf, "package %s\n\n", imp.pkgName)
+
+	paths := make([]string, 0, len(imp.imports))
+	for path := range imp.imports {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	buf.WriteString("import (\n")
+	for _, path := range paths {
+		fmt.Fprintf(&buf, "\t%s\n", strconv.Quote(path))
+	}
+	buf.WriteString(")\n")
+
+	if imp.usesID {
+		buf.WriteString(`
+// ID is the ID scalar.
+type ID string
+
+// GraphqlType describes ID as the ID scalar, instead of String.
+func (ID) GraphqlType() graphql.Type {
+	return graphql.ID
+}
+`)
+	}
+	buf.Write(body.Bytes())
+
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("formatting the generated code: %s", err)
+	}
+	return src, nil
+}
+
+func (imp *importer) writeScalar(buf *bytes.Buffer, def *ast.ScalarDefinition) error {
+	name := def.Name.Value
+	if _, ok := builtinTypes[name]; ok {
+		// The scalar is already known by the encoder.
+		return nil
+	}
+	imp.imports[graphqlPath+"/language/ast"] = true
+
+	buf.WriteString("\n")
+	writeComment(buf, "", description(def.Description))
+	fmt.Fprintf(buf, `type %[1]s string
+
+var %[2]sScalar = graphql.NewScalar(graphql.ScalarConfig{
+	Name:        %[3]s,
+	Description: %[4]s,
+	Serialize: func(value interface{}) interface{} {
+		return value
+	},
+	ParseValue: func(value interface{}) interface{} {
+		return value
+	},
+	ParseLiteral: func(valueAST ast.Value) interface{} {
+		if value, ok := valueAST.(*ast.StringValue); ok {
+			return value.Value
+		}
+		return nil
+	},
+})
+
+// GraphqlType describes %[1]s as the %[1]s scalar.
+func (%[1]s) GraphqlType() graphql.Type {
+	return %[2]sScalar
+}
+`, name, unexported(name), strconv.Quote(name), strconv.Quote(description(def.Description)))
+	return nil
+}
+
+func (imp *importer) writeEnum(buf *bytes.Buffer, def *ast.EnumDefinition) error {
+	name := def.Name.Value
+
+	buf.WriteString("\n")
+	writeComment(buf, "", description(def.Description))
+	fmt.Fprintf(buf, "type %s string\n\nconst (\n", name)
+	var values bytes.Buffer
+	for _, value := range def.Values {
+		constName := name + goName(value.Name.Value)
+		writeComment(buf, "\t", description(value.Description))
+		fmt.Fprintf(buf, "\t%s %s = %s\n", constName, name, strconv.Quote(value.Name.Value))
+
+		var options []string
+		if desc := description(value.Description); desc != "" {
+			options = append(options, "gqlstruct.WithDescription("+strconv.Quote(desc)+")")
+		}
+		if reason, ok := deprecationReason(value.Directives); ok {
+			options = append(options, "gqlstruct.WithDeprecationReason("+strconv.Quote(reason)+")")
+		}
+		fmt.Fprintf(&values, "\t\tgqlstruct.NewEnumValue(%s),\n", strings.Join(append([]string{constName}, options...), ", "))
+	}
+	fmt.Fprintf(buf, `)
+
+// GraphqlEnumValues describes %[1]s as an enum.
+func (%[1]s) GraphqlEnumValues() []gqlstruct.EnumValue {
+	return []gqlstruct.EnumValue{
+%[2]s	}
+}
+`, name, values.String())
+	return nil
+}
+
+func (imp *importer) writeInterface(buf *bytes.Buffer, def *ast.InterfaceDefinition) error {
+	name := def.Name.Value
+	var fields bytes.Buffer
+	for _, field := range def.Fields {
+		if len(field.Arguments) > 0 {
+			imp.warn("%s.%s: the fields of interfaces cannot have arguments, the field is ignored", name, field.Name.Value)
+			continue
+		}
+		if err := imp.writeField(&fields, name, field); err != nil {
+			return err
+		}
+	}
+
+	buf.WriteString("\n")
+	writeComment(buf, "", description(def.Description))
+	fmt.Fprintf(buf, "type %s %s\n", name, structOf(fields.String()))
+	return nil
+}
+
+func (imp *importer) writeUnion(buf *bytes.Buffer, def *ast.UnionDefinition) error {
+	name := def.Name.Value
+	buf.WriteString("\n")
+	writeComment(buf, "", description(def.Description))
+	fmt.Fprintf(buf, "type %s interface {\n\tis%s()\n}\n", name, name)
+	return nil
+}
+
+func (imp *importer) writeObject(buf *bytes.Buffer, def *ast.ObjectDefinition) error {
+	name := def.Name.Value
+
+	// The fields declared the same way by the interfaces are promoted from
+	// the embedded structs.
+	promoted := make(map[string]string)
+	var fields bytes.Buffer
+	for _, iface := range def.Interfaces {
+		ifaceDef, ok := imp.types[iface.Name.Value].(*ast.InterfaceDefinition)
+		if !ok {
+			return fmt.Errorf("%s: %s is not an interface", name, iface.Name.Value)
+		}
+		for _, field := range ifaceDef.Fields {
+			if _, ok := promoted[field.Name.Value]; !ok {
+				promoted[field.Name.Value] = printNode(field)
+			}
+		}
+		fmt.Fprintf(&fields, "\t%s `graphql:\",interface\"`\n", iface.Name.Value)
+	}
+
+	var methods []*ast.FieldDefinition
+	for _, field := range def.Fields {
+		if printed, ok := promoted[field.Name.Value]; ok && printed == printNode(field) {
+			continue
+		}
+		if len(field.Arguments) > 0 {
+			methods = append(methods, field)
+			continue
+		}
+		if err := imp.writeField(&fields, name, field); err != nil {
+			return err
+		}
+	}
+
+	buf.WriteString("\n")
+	writeComment(buf, "", description(def.Description))
+	fmt.Fprintf(buf, "type %s %s\n", name, structOf(fields.String()))
+
+	for _, union := range imp.unions[name] {
+		fmt.Fprintf(buf, "\nfunc (%s) is%s() {}\n", name, union)
+	}
+
+	if len(methods) == 0 {
+		return nil
+	}
+	imp.imports["context"] = true
+	imp.imports["errors"] = true
+
+	buf.WriteString("\n// GraphqlMethods exposes the fields with arguments.\n")
+	fmt.Fprintf(buf, "func (*%s) GraphqlMethods() map[string]string {\n\treturn map[string]string{\n", name)
+	for _, field := range methods {
+		tag, err := imp.tag(name, field.Name.Value, field.Type, field.Description, field.Directives, nil)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(buf, "\t\t%s: %s,\n", strconv.Quote(goName(field.Name.Value)), strconv.Quote(tag))
+	}
+	buf.WriteString("\t}\n}\n")
+
+	for _, field := range methods {
+		path := name + "." + field.Name.Value
+		argsName := name + goName(field.Name.Value) + "Args"
+		returnType, err := imp.goType(path, nonNullOf(field.Type), false)
+		if err != nil {
+			return err
+		}
+
+		var args bytes.Buffer
+		for _, arg := range field.Arguments {
+			if err := imp.writeInputValue(&args, path, arg); err != nil {
+				return err
+			}
+		}
+		fmt.Fprintf(buf, `
+// %[1]s are the arguments of %[2]s.
+type %[1]s %[3]s
+
+// %[4]s resolves %[2]s.
+func (*%[5]s) %[4]s(ctx context.Context, args %[1]s) (%[6]s, error) {
+	return %[7]s, errors.New("%[2]s: not implemented")
+}
+`, argsName, path, structOf(args.String()), goName(field.Name.Value), name, returnType, imp.zeroValue(returnType))
+	}
+	return nil
+}
+
+func (imp *importer) writeInput(buf *bytes.Buffer, def *ast.InputObjectDefinition) error {
+	name := def.Name.Value
+	if !strings.HasSuffix(name, inputSuffix) {
+		imp.warn("%s: the name of input objects ends with %q, it will be described as %s%s", name, inputSuffix, name, inputSuffix)
+	}
+
+	var fields bytes.Buffer
+	for _, field := range def.Fields {
+		if err := imp.writeInputValue(&fields, name, field); err != nil {
+			return err
+		}
+	}
+
+	buf.WriteString("\n")
+	writeComment(buf, "", description(def.Description))
+	fmt.Fprintf(buf, "type %s %s\n", name, structOf(fields.String()))
+	return nil
+}
+
+// writeField writes the struct field of an object or interface field.
+func (imp *importer) writeField(buf *bytes.Buffer, typeName string, field *ast.FieldDefinition) error {
+	tag, err := imp.tag(typeName, field.Name.Value, field.Type, field.Description, field.Directives, nil)
+	if err != nil {
+		return err
+	}
+	goType, err := imp.goType(typeName+"."+field.Name.Value, nonNullOf(field.Type), false)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(buf, "\t%s %s %s\n", goName(field.Name.Value), goType, structTag(tag))
+	return nil
+}
+
+// writeInputValue writes the struct field of an argument or input field.
+func (imp *importer) writeInputValue(buf *bytes.Buffer, typeName string, value *ast.InputValueDefinition) error {
+	tag, err := imp.tag(typeName, value.Name.Value, value.Type, value.Description, value.Directives, value.DefaultValue)
+	if err != nil {
+		return err
+	}
+	goType, err := imp.goType(typeName+"."+value.Name.Value, nonNullOf(value.Type), false)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(buf, "\t%s %s %s\n", goName(value.Name.Value), goType, structTag(tag))
+	return nil
+}
+
+// tag returns the value of the "graphql" tag of a field.
+func (imp *importer) tag(typeName, name string, t ast.Type, desc *ast.StringValue, directives []*ast.Directive, defaultValue ast.Value) (string, error) {
+	tag := name
+	if _, ok := t.(*ast.NonNull); ok {
+		tag = "!" + tag
+	}
+	if d := description(desc); d != "" {
+		tag += ",desc=" + escapeTagValue(d)
+	}
+	if reason, ok := deprecationReason(directives); ok {
+		tag += ",deprecated=" + escapeTagValue(reason)
+	}
+	if defaultValue != nil {
+		value, ok := defaultString(defaultValue)
+		if ok {
+			tag += ",default=" + escapeTagValue(value)
+		} else {
+			imp.warn("%s.%s: the default value %s is not supported, it is ignored", typeName, name, printNode(defaultValue))
+		}
+	}
+	return tag, nil
+}
+
+// goType returns the Go type of the GraphQL type informed. Objects,
+// interfaces and input objects are referenced by pointers, unless they are
+// items of lists.
+func (imp *importer) goType(path string, t ast.Type, listItem bool) (string, error) {
+	switch t := t.(type) {
+	case *ast.NonNull:
+		imp.warn("%s: non null list items are not supported, the items will be nullable", path)
+		return imp.goType(path, t.Type, listItem)
+	case *ast.List:
+		elem, err := imp.goType(path, t.Type, true)
+		if err != nil {
+			return "", err
+		}
+		return "[]" + elem, nil
+	case *ast.Named:
+		name := t.Name.Value
+		if goType, ok := builtinTypes[name]; ok {
+			switch name {
+			case "ID":
+				imp.usesID = true
+			case "DateTime":
+				imp.imports["time"] = true
+			}
+			return goType, nil
+		}
+		switch imp.types[name].(type) {
+		case *ast.ScalarDefinition, *ast.EnumDefinition, *ast.UnionDefinition:
+			return name, nil
+		case *ast.ObjectDefinition, *ast.InterfaceDefinition, *ast.InputObjectDefinition:
+			if listItem {
+				return name, nil
+			}
+			return "*" + name, nil
+		}
+		return "", fmt.Errorf("%s: unknown type %s", path, name)
+	}
+	return "", fmt.Errorf("%s: unknown type %s", path, printNode(t))
+}
+
+// writeRegisterTypes writes the function that registers the types in the
+// encoder.
+//
+// The enums are registered first, followed by the interfaces and unions, as
+// they must be known before the structs that use them are built. Then, the
+// objects and input objects are registered after their dependencies, so
+// their descriptions are applied before they are built as a dependency of
+// another type.
+func (imp *importer) writeRegisterTypes(buf *bytes.Buffer) {
+	var calls []string
+	built := make(map[string]bool)
+	register := func(name string) {
+		call, desc := imp.registerCall(name)
+		if built[name] {
+			if desc != "" {
+				imp.warn("%s: the description is not applied, as %s is built as a dependency of another type", name, name)
+			}
+			return
+		}
+		imp.reach(name, built)
+		if desc != "" {
+			call += ", gqlstruct.WithDescription(" + strconv.Quote(desc) + ")"
+		}
+		calls = append(calls, call+")")
+	}
+
+	for _, def := range imp.defs {
+		if _, ok := def.(*ast.EnumDefinition); ok {
+			register(nameOf(def))
+		}
+	}
+
+	visited := make(map[string]bool)
+	var visitAbstract, visitConcrete func(name string)
+	visitAbstract = func(name string) {
+		if visited[name] {
+			return
+		}
+		visited[name] = true
+		for _, dep := range imp.abstractDeps(name) {
+			visitAbstract(dep)
+		}
+		// The objects and input objects that can be built before the
+		// abstract type are registered first, so their descriptions are
+		// applied.
+		for _, dep := range imp.reachOrder(name) {
+			switch imp.types[dep].(type) {
+			case *ast.ObjectDefinition, *ast.InputObjectDefinition:
+				if !imp.reachesUnregistered(dep, built) {
+					visitConcrete(dep)
+				}
+			}
+		}
+		register(name)
+	}
+	visitConcrete = func(name string) {
+		if visited[name] {
+			return
+		}
+		visited[name] = true
+		for _, dep := range imp.deps(name) {
+			switch imp.types[dep].(type) {
+			case *ast.ObjectDefinition, *ast.InputObjectDefinition:
+				visitConcrete(dep)
+			}
+		}
+		register(name)
+	}
+	for _, def := range imp.defs {
+		switch def.(type) {
+		case *ast.InterfaceDefinition, *ast.UnionDefinition:
+			visitAbstract(nameOf(def))
+		}
+	}
+
+	for _, def := range imp.defs {
+		switch def.(type) {
+		case *ast.ObjectDefinition, *ast.InputObjectDefinition:
+			visitConcrete(nameOf(def))
+		}
+	}
+
+	fmt.Fprintf(buf, `
+// RegisterTypes registers the types of the schema in the encoder. It must be
+// called before building the schema, so the enums, interfaces and unions are
+// known before the structs that use them.
+func RegisterTypes(enc %s) error {
+`, encoderMethods)
+	for _, call := range calls {
+		fmt.Fprintf(buf, "\tif _, err := %s; err != nil {\n\t\treturn err\n\t}\n", call)
+	}
+	buf.WriteString("\treturn nil\n}\n")
+}
+
+// registerCall returns the call, without the closing parenthesis, that
+// registers the type in the encoder and the description of the type.
+func (imp *importer) registerCall(name string) (string, string) {
+	switch def := imp.types[name].(type) {
+	case *ast.EnumDefinition:
+		return fmt.Sprintf("enc.RegisterEnum(%[1]s(\"\"), %[1]s(\"\").GraphqlEnumValues()", name), description(def.Description)
+	case *ast.InterfaceDefinition:
+		return fmt.Sprintf("enc.Interface(&%s{}", name), description(def.Description)
+	case *ast.UnionDefinition:
+		members := make([]string, len(def.Types))
+		for i, member := range def.Types {
+			members[i] = "&" + member.Name.Value + "{}"
+		}
+		return fmt.Sprintf("enc.RegisterUnion((*%s)(nil), []interface{}{%s}", name, strings.Join(members, ", ")), description(def.Description)
+	case *ast.ObjectDefinition:
+		return fmt.Sprintf("enc.Struct(&%s{}", name), description(def.Description)
+	case *ast.InputObjectDefinition:
+		return fmt.Sprintf("enc.InputObject(&%s{}", name), description(def.Description)
+	}
+	return "", ""
+}
+
+// deps returns the names of the types referenced by the type informed.
+// They are built along with it.
+func (imp *importer) deps(name string) []string {
+	var deps []string
+	switch def := imp.types[name].(type) {
+	case *ast.ObjectDefinition:
+		for _, iface := range def.Interfaces {
+			deps = append(deps, iface.Name.Value)
+		}
+		for _, field := range def.Fields {
+			deps = append(deps, namedOf(field.Type))
+			for _, arg := range field.Arguments {
+				deps = append(deps, namedOf(arg.Type))
+			}
+		}
+	case *ast.InterfaceDefinition:
+		for _, field := range def.Fields {
+			deps = append(deps, namedOf(field.Type))
+		}
+	case *ast.UnionDefinition:
+		for _, member := range def.Types {
+			deps = append(deps, member.Name.Value)
+		}
+	case *ast.InputObjectDefinition:
+		for _, field := range def.Fields {
+			deps = append(deps, namedOf(field.Type))
+		}
+	}
+	return deps
+}
+
+// reach adds the type, and all types built along with it, to seen.
+func (imp *importer) reach(name string, seen map[string]bool) {
+	if seen[name] {
+		return
+	}
+	seen[name] = true
+	for _, dep := range imp.deps(name) {
+		imp.reach(dep, seen)
+	}
+}
+
+// reachOrder returns the types built along with the type informed, in the
+// order they are found.
+func (imp *importer) reachOrder(name string) []string {
+	var r []string
+	seen := map[string]bool{name: true}
+	var walk func(name string)
+	walk = func(name string) {
+		for _, dep := range imp.deps(name) {
+			if seen[dep] {
+				continue
+			}
+			seen[dep] = true
+			r = append(r, dep)
+			walk(dep)
+		}
+	}
+	walk(name)
+	return r
+}
+
+// reachesUnregistered checks if building the type informed would build an
+// interface or union that was not registered yet.
+func (imp *importer) reachesUnregistered(name string, registered map[string]bool) bool {
+	reached := make(map[string]bool)
+	imp.reach(name, reached)
+	for dep := range reached {
+		switch imp.types[dep].(type) {
+		case *ast.InterfaceDefinition, *ast.UnionDefinition:
+			if !registered[dep] {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// abstractDeps returns the interfaces and unions that are referenced by the
+// type informed, directly or through objects and input objects.
+func (imp *importer) abstractDeps(name string) []string {
+	var r []string
+	seen := map[string]bool{name: true}
+	var walk func(name string)
+	walk = func(name string) {
+		for _, dep := range imp.deps(name) {
+			if seen[dep] {
+				continue
+			}
+			seen[dep] = true
+			switch imp.types[dep].(type) {
+			case *ast.InterfaceDefinition, *ast.UnionDefinition:
+				r = append(r, dep)
+			default:
+				walk(dep)
+			}
+		}
+	}
+	walk(name)
+	return r
+}
+
+func nameOf(def ast.Node) string {
+	switch def := def.(type) {
+	case *ast.ScalarDefinition:
+		return def.Name.Value
+	case *ast.ObjectDefinition:
+		return def.Name.Value
+	case *ast.InterfaceDefinition:
+		return def.Name.Value
+	case *ast.UnionDefinition:
+		return def.Name.Value
+	case *ast.EnumDefinition:
+		return def.Name.Value
+	case *ast.InputObjectDefinition:
+		return def.Name.Value
+	}
+	return ""
+}
+
+// namedOf returns the name of the named type wrapped by t.
+func namedOf(t ast.Type) string {
+	for {
+		switch tt := t.(type) {
+		case *ast.NonNull:
+			t = tt.Type
+		case *ast.List:
+			t = tt.Type
+		case *ast.Named:
+			return tt.Name.Value
+		default:
+			return ""
+		}
+	}
+}
+
+// nonNullOf removes the outer NonNull of the type, as it is described by
+// the tag.
+func nonNullOf(t ast.Type) ast.Type {
+	if nonNull, ok := t.(*ast.NonNull); ok {
+		return nonNull.Type
+	}
+	return t
+}
+
+func description(value *ast.StringValue) string {
+	if value == nil {
+		return ""
+	}
+	return value.Value
+}
+
+// deprecationReason returns the reason of the `@deprecated` directive.
+func deprecationReason(directives []*ast.Directive) (string, bool) {
+	for _, directive := range directives {
+		if directive.Name.Value != "deprecated" {
+			continue
+		}
+		for _, arg := range directive.Arguments {
+			if s, ok := arg.Value.(*ast.StringValue); ok && arg.Name.Value == "reason" {
+				return s.Value, true
+			}
+		}
+		return graphql.DefaultDeprecationReason, true
+	}
+	return "", false
+}
+
+// defaultString returns the default value as it is written in the tag.
+func defaultString(value ast.Value) (string, bool) {
+	switch value := value.(type) {
+	case *ast.StringValue:
+		return value.Value, true
+	case *ast.IntValue:
+		return value.Value, true
+	case *ast.FloatValue:
+		return value.Value, true
+	case *ast.EnumValue:
+		return value.Value, true
+	case *ast.BooleanValue:
+		return strconv.FormatBool(value.Value), true
+	}
+	return "", false
+}
+
+func printNode(node ast.Node) string {
+	s, _ := printer.Print(node).(string)
+	return s
+}
+
+// escapeTagValue escapes the commas of the values of the tag options.
+func escapeTagValue(value string) string {
+	value = strings.Replace(value, `\`, `\\`, -1)
+	return strings.Replace(value, ",", `\,`, -1)
+}
+
+// structTag returns the struct tag literal with the "graphql" tag informed.
+func structTag(tag string) string {
+	s := "graphql:" + strconv.Quote(tag)
+	if strings.Contains(s, "`") {
+		return strconv.Quote(s)
+	}
+	return "`" + s + "`"
+}
+
+// zeroValue returns the zero value of the Go type informed.
+func (imp *importer) zeroValue(goType string) string {
+	switch goType {
+	case "string", "ID":
+		return `""`
+	case "int", "float64":
+		return "0"
+	case "bool":
+		return "false"
+	case "time.Time":
+		return "time.Time{}"
+	}
+	if strings.HasPrefix(goType, "*") || strings.HasPrefix(goType, "[]") {
+		return "nil"
+	}
+	if _, ok := imp.types[goType].(*ast.UnionDefinition); ok {
+		return "nil"
+	}
+	// Custom scalars and enums.
+	return goType + `("")`
+}
+
+// structOf returns the struct type with the fields informed.
+func structOf(fields string) string {
+	if fields == "" {
+		return "struct{}"
+	}
+	return "struct {\n" + fields + "}"
+}
+
+func writeComment(buf *bytes.Buffer, indent, text string) {
+	if text == "" {
+		return
+	}
+	for _, line := range strings.Split(text, "\n") {
+		fmt.Fprintf(buf, "%s// %s\n", indent, line)
+	}
+}
+
+// goName converts a GraphQL name to an exported Go name: "createdAt"
+// becomes "CreatedAt", "IN_PROGRESS" becomes "InProgress" and "userId"
+// becomes "UserID".
+func goName(name string) string {
+	var b strings.Builder
+	for _, word := range splitWords(name) {
+		upper := strings.ToUpper(word)
+		if initialisms[upper] {
+			b.WriteString(upper)
+			continue
+		}
+		b.WriteString(upper[:1])
+		b.WriteString(strings.ToLower(word[1:]))
+	}
+	if b.Len() == 0 {
+		return "X"
+	}
+	return b.String()
+}
+
+// unexported converts a GraphQL name to an unexported Go name.
+func unexported(name string) string {
+	s := goName(name)
+	upper := strings.ToUpper(s)
+	if initialisms[upper] {
+		return strings.ToLower(s)
+	}
+	return strings.ToLower(s[:1]) + s[1:]
+}
+
+// splitWords splits a name in words by the underscores and by the changes
+// of case.
+func splitWords(name string) []string {
+	var words []string
+	runes := []rune(name)
+	start := 0
+	for i := 0; i <= len(runes); i++ {
+		boundary := i == len(runes) || runes[i] == '_'
+		if !boundary && i > start && unicode.IsUpper(runes[i]) {
+			// "userName" splits before "N" and "HTMLBody" splits before "B".
+			boundary = !unicode.IsUpper(runes[i-1]) ||
+				(i+1 < len(runes) && unicode.IsLower(runes[i+1]))
+		}
+		if !boundary {
+			continue
+		}
+		if i > start {
+			words = append(words, string(runes[start:i]))
+		}
+		if i < len(runes) && runes[i] == '_' {
+			start = i + 1
+		} else {
+			start = i
+		}
+	}
+	return words
+}
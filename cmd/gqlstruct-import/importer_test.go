@@ -0,0 +1,114 @@
+package main
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"io/ioutil"
+)
+
+// importSDL generates the code of the SDL, returning it along with the
+// warnings.
+func importSDL(sdl string) (string, []string) {
+	imp, err := newImporter("models", "schema.graphql", sdl)
+	Expect(err).ToNot(HaveOccurred())
+	src, err := imp.generate()
+	Expect(err).ToNot(HaveOccurred())
+	return string(src), imp.warnings
+}
+
+var _ = Describe("Importer", func() {
+	It("should generate the committed code of the example", func() {
+		sdl, err := ioutil.ReadFile("./internal/example/schema.graphql")
+		Expect(err).ToNot(HaveOccurred())
+
+		imp, err := newImporter("example", "./internal/example/schema.graphql", string(sdl))
+		Expect(err).ToNot(HaveOccurred())
+		src, err := imp.generate()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(imp.warnings).To(BeEmpty())
+
+		committed, err := ioutil.ReadFile("./internal/example/example.go")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(string(src)).To(Equal(string(committed)), "run `go generate ./cmd/gqlstruct-import/internal/example`")
+	})
+
+	It("should warn about non null list items", func() {
+		src, warnings := importSDL(`type Query { tags: [String!]! }`)
+		Expect(src).To(ContainSubstring("Tags []string `graphql:\"!tags\"`"))
+		Expect(warnings).To(ConsistOf("Query.tags: non null list items are not supported, the items will be nullable"))
+	})
+
+	It("should warn about input objects not ending with Input", func() {
+		_, warnings := importSDL(`
+			type Query { users(filter: UserFilter): String }
+			input UserFilter { name: String }
+		`)
+		Expect(warnings).To(ConsistOf(`UserFilter: the name of input objects ends with "Input", it will be described as UserFilterInput`))
+	})
+
+	It("should warn about the arguments of interface fields", func() {
+		src, warnings := importSDL(`
+			type Query { node: Node }
+			interface Node { id: ID! children(first: Int): [Node] }
+		`)
+		Expect(src).ToNot(ContainSubstring("Children"))
+		Expect(warnings).To(ConsistOf("Node.children: the fields of interfaces cannot have arguments, the field is ignored"))
+	})
+
+	It("should warn about the default values that are not supported", func() {
+		_, warnings := importSDL(`
+			type Query { users(filter: FilterInput = {name: "a"}): String }
+			input FilterInput { name: String }
+		`)
+		Expect(warnings).To(ConsistOf(`Query.users.filter: the default value {name: "a"} is not supported, it is ignored`))
+	})
+
+	It("should warn about the descriptions lost in cycles", func() {
+		_, warnings := importSDL(`
+			type Query { result: Result }
+			type User { orders: [Order] }
+			"""A purchase."""
+			type Order { related: [Result] }
+			union Result = User
+		`)
+		Expect(warnings).To(ConsistOf("Order: the description is not applied, as Order is built as a dependency of another type"))
+	})
+
+	It("should fail with unknown types", func() {
+		imp, err := newImporter("models", "schema.graphql", `type Query { user: User }`)
+		Expect(err).ToNot(HaveOccurred())
+		_, err = imp.generate()
+		Expect(err).To(MatchError("Query.user: unknown type User"))
+	})
+
+	It("should fail with types defined more than once", func() {
+		_, err := newImporter("models", "schema.graphql", `type User { id: ID } type User { name: String }`)
+		Expect(err).To(MatchError("User: defined more than once"))
+	})
+
+	It("should fail with union members that are not objects", func() {
+		_, err := newImporter("models", "schema.graphql", `union Result = Node interface Node { id: ID }`)
+		Expect(err).To(MatchError("Result: the members of unions must be objects, Node is not"))
+	})
+
+	It("should fail with invalid SDL", func() {
+		_, err := newImporter("models", "schema.graphql", `type Query {`)
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("should convert the GraphQL names to Go names", func() {
+		names := map[string]string{
+			"createdAt":   "CreatedAt",
+			"IN_PROGRESS": "InProgress",
+			"userId":      "UserID",
+			"HTMLBody":    "HTMLBody",
+			"url":         "URL",
+			"_":           "X",
+		}
+		for name, expected := range names {
+			Expect(goName(name)).To(Equal(expected), name)
+		}
+		Expect(unexported("ID")).To(Equal("id"))
+		Expect(unexported("SearchResult")).To(Equal("searchResult"))
+	})
+})
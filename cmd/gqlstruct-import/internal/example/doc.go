@@ -0,0 +1,5 @@
+// Package example has the code generated by gqlstruct-import from
+// schema.graphql, used to verify that the structs describe the same schema.
+package example
+
+//go:generate go run github.com/lab259/go-graphql-struct/cmd/gqlstruct-import -package example -output example.go schema.graphql
@@ -0,0 +1,244 @@
+// Generated by gqlstruct-import from schema.graphql.
+//
+// The stubs of the methods and scalars have to be implemented.
+
+package example
+
+import (
+	"context"
+	"errors"
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/language/ast"
+	"github.com/lab259/go-graphql-struct"
+	"time"
+)
+
+// ID is the ID scalar.
+type ID string
+
+// GraphqlType describes ID as the ID scalar, instead of String.
+func (ID) GraphqlType() graphql.Type {
+	return graphql.ID
+}
+
+type Query struct {
+	Now time.Time `graphql:"now"`
+}
+
+// GraphqlMethods exposes the fields with arguments.
+func (*Query) GraphqlMethods() map[string]string {
+	return map[string]string{
+		"User":   "user,desc=The user with the ID informed.",
+		"Users":  "!users",
+		"Search": "search",
+		"Node":   "node",
+	}
+}
+
+// QueryUserArgs are the arguments of Query.user.
+type QueryUserArgs struct {
+	ID ID `graphql:"!id"`
+}
+
+// User resolves Query.user.
+func (*Query) User(ctx context.Context, args QueryUserArgs) (*User, error) {
+	return nil, errors.New("Query.user: not implemented")
+}
+
+// QueryUsersArgs are the arguments of Query.users.
+type QueryUsersArgs struct {
+	First  int              `graphql:"first,default=10"`
+	Status Status           `graphql:"status,default=ACTIVE"`
+	Filter *UserFilterInput `graphql:"filter"`
+}
+
+// Users resolves Query.users.
+func (*Query) Users(ctx context.Context, args QueryUsersArgs) ([]User, error) {
+	return nil, errors.New("Query.users: not implemented")
+}
+
+// QuerySearchArgs are the arguments of Query.search.
+type QuerySearchArgs struct {
+	Term string `graphql:"!term"`
+}
+
+// Search resolves Query.search.
+func (*Query) Search(ctx context.Context, args QuerySearchArgs) ([]SearchResult, error) {
+	return nil, errors.New("Query.search: not implemented")
+}
+
+// QueryNodeArgs are the arguments of Query.node.
+type QueryNodeArgs struct {
+	ID ID `graphql:"!id"`
+}
+
+// Node resolves Query.node.
+func (*Query) Node(ctx context.Context, args QueryNodeArgs) (*Node, error) {
+	return nil, errors.New("Query.node: not implemented")
+}
+
+type Mutation struct{}
+
+// GraphqlMethods exposes the fields with arguments.
+func (*Mutation) GraphqlMethods() map[string]string {
+	return map[string]string{
+		"CreateUser": "createUser",
+	}
+}
+
+// MutationCreateUserArgs are the arguments of Mutation.createUser.
+type MutationCreateUserArgs struct {
+	Input *CreateUserInput `graphql:"!input"`
+}
+
+// CreateUser resolves Mutation.createUser.
+func (*Mutation) CreateUser(ctx context.Context, args MutationCreateUserArgs) (*User, error) {
+	return nil, errors.New("Mutation.createUser: not implemented")
+}
+
+// An object with an ID.
+type Node struct {
+	ID ID `graphql:"!id"`
+}
+
+// The status of an user.
+type Status string
+
+const (
+	// The user can sign in.
+	StatusActive   Status = "ACTIVE"
+	StatusBlocked  Status = "BLOCKED"
+	StatusInReview Status = "IN_REVIEW"
+)
+
+// GraphqlEnumValues describes Status as an enum.
+func (Status) GraphqlEnumValues() []gqlstruct.EnumValue {
+	return []gqlstruct.EnumValue{
+		gqlstruct.NewEnumValue(StatusActive, gqlstruct.WithDescription("The user can sign in.")),
+		gqlstruct.NewEnumValue(StatusBlocked),
+		gqlstruct.NewEnumValue(StatusInReview, gqlstruct.WithDeprecationReason("Users are not reviewed anymore.")),
+	}
+}
+
+// An amount of money, in cents.
+type Money string
+
+var moneyScalar = graphql.NewScalar(graphql.ScalarConfig{
+	Name:        "Money",
+	Description: "An amount of money, in cents.",
+	Serialize: func(value interface{}) interface{} {
+		return value
+	},
+	ParseValue: func(value interface{}) interface{} {
+		return value
+	},
+	ParseLiteral: func(valueAST ast.Value) interface{} {
+		if value, ok := valueAST.(*ast.StringValue); ok {
+			return value.Value
+		}
+		return nil
+	},
+})
+
+// GraphqlType describes Money as the Money scalar.
+func (Money) GraphqlType() graphql.Type {
+	return moneyScalar
+}
+
+type User struct {
+	Node      `graphql:",interface"`
+	Name      string    `graphql:"!name,deprecated=No longer supported"`
+	Email     string    `graphql:"email"`
+	Status    Status    `graphql:"status"`
+	CreatedAt time.Time `graphql:"createdAt"`
+}
+
+func (User) isSearchResult() {}
+
+// GraphqlMethods exposes the fields with arguments.
+func (*User) GraphqlMethods() map[string]string {
+	return map[string]string{
+		"Orders": "orders",
+	}
+}
+
+// UserOrdersArgs are the arguments of User.orders.
+type UserOrdersArgs struct {
+	Last int `graphql:"last"`
+}
+
+// Orders resolves User.orders.
+func (*User) Orders(ctx context.Context, args UserOrdersArgs) ([]Order, error) {
+	return nil, errors.New("User.orders: not implemented")
+}
+
+// A purchase.
+type Order struct {
+	Node  `graphql:",interface"`
+	Total Money      `graphql:"total"`
+	Buyer *User      `graphql:"buyer"`
+	Items [][]string `graphql:"items,desc=The items of the order\\, by product ID."`
+}
+
+type Group struct {
+	Title   string `graphql:"!title"`
+	Members []User `graphql:"members"`
+}
+
+func (Group) isSearchResult() {}
+
+// The results of a search.
+type SearchResult interface {
+	isSearchResult()
+}
+
+type UserFilterInput struct {
+	Name   string `graphql:"name,desc=Matches the name\\, or part of it."`
+	Status Status `graphql:"status,default=BLOCKED"`
+}
+
+type CreateUserInput struct {
+	Name   string           `graphql:"!name"`
+	Email  string           `graphql:"email,default=nobody@example.com\\, really"`
+	Filter *UserFilterInput `graphql:"filter"`
+}
+
+// RegisterTypes registers the types of the schema in the encoder. It must be
+// called before building the schema, so the enums, interfaces and unions are
+// known before the structs that use them.
+func RegisterTypes(enc interface {
+	RegisterEnum(obj interface{}, values []gqlstruct.EnumValue, options ...gqlstruct.ObjectOption) (*graphql.Enum, error)
+	Interface(obj interface{}, options ...gqlstruct.ObjectOption) (*graphql.Interface, error)
+	RegisterUnion(iface interface{}, members []interface{}, options ...gqlstruct.ObjectOption) (*graphql.Union, error)
+	Struct(obj interface{}, options ...gqlstruct.StructOption) (*graphql.Object, error)
+	InputObject(obj interface{}, options ...gqlstruct.ObjectOption) (*graphql.InputObject, error)
+}) error {
+	if _, err := enc.RegisterEnum(Status(""), Status("").GraphqlEnumValues(), gqlstruct.WithDescription("The status of an user.")); err != nil {
+		return err
+	}
+	if _, err := enc.Interface(&Node{}, gqlstruct.WithDescription("An object with an ID.")); err != nil {
+		return err
+	}
+	if _, err := enc.Struct(&Order{}, gqlstruct.WithDescription("A purchase.")); err != nil {
+		return err
+	}
+	if _, err := enc.Struct(&Group{}); err != nil {
+		return err
+	}
+	if _, err := enc.RegisterUnion((*SearchResult)(nil), []interface{}{&User{}, &Group{}}, gqlstruct.WithDescription("The results of a search.")); err != nil {
+		return err
+	}
+	if _, err := enc.InputObject(&UserFilterInput{}); err != nil {
+		return err
+	}
+	if _, err := enc.Struct(&Query{}); err != nil {
+		return err
+	}
+	if _, err := enc.InputObject(&CreateUserInput{}); err != nil {
+		return err
+	}
+	if _, err := enc.Struct(&Mutation{}); err != nil {
+		return err
+	}
+	return nil
+}
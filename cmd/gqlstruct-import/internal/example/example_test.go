@@ -0,0 +1,15 @@
+package example_test
+
+import (
+	"github.com/jamillosantos/macchiato"
+	"github.com/onsi/ginkgo"
+	"github.com/onsi/gomega"
+	"log"
+	"testing"
+)
+
+func TestExample(t *testing.T) {
+	log.SetOutput(ginkgo.GinkgoWriter)
+	gomega.RegisterFailHandler(ginkgo.Fail)
+	macchiato.RunSpecs(t, "gqlstruct-import: Generated Example Test Suite")
+}
@@ -0,0 +1,26 @@
+package example_test
+
+import (
+	"github.com/lab259/go-graphql-struct"
+	"github.com/lab259/go-graphql-struct/cmd/gqlstruct-import/internal/example"
+	"github.com/lab259/go-graphql-struct/diff"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"io/ioutil"
+)
+
+var _ = Describe("Generated code", func() {
+	It("should describe the same schema as the SDL file", func() {
+		enc := gqlstruct.NewEncoder()
+		Expect(example.RegisterTypes(enc)).To(Succeed())
+		schema, err := enc.Schema(&example.Query{}, &example.Mutation{}, nil)
+		Expect(err).ToNot(HaveOccurred())
+
+		sdl, err := ioutil.ReadFile("schema.graphql")
+		Expect(err).ToNot(HaveOccurred())
+
+		changes, err := diff.SDL(string(sdl), gqlstruct.PrintSchema(schema))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(changes).To(BeEmpty())
+	})
+})
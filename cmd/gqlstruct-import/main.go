@@ -0,0 +1,82 @@
+// Command gqlstruct-import generates tagged Go structs from a GraphQL SDL
+// file. It is meant to migrate services that already have a schema:
+//
+// ```
+// gqlstruct-import -package models -output models.go schema.graphql
+// ```
+//
+// The generated code follows the conventions of gqlstruct:
+//
+// * Objects, interfaces and input objects become tagged structs;
+// * Objects embed the interfaces they implement;
+// * Fields with arguments become method stubs that receive args structs;
+// * Enums become string types with constants and `GraphqlEnumValues`;
+// * Unions become sealed interfaces implemented by their members;
+// * Custom scalars become types with a `GraphqlType` stub.
+//
+// A `RegisterTypes` function registers the enums, interfaces and unions in
+// the encoder, as they must be known before the structs using them are
+// built.
+//
+// Whatever the tags cannot describe (eg. the descriptions of objects or non
+// null list items) is reported as a warning.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+)
+
+var (
+	packageName = flag.String("package", "", "name of the package of the generated code; required")
+	output      = flag.String("output", "", "output file name; default stdout")
+)
+
+func usage() {
+	fmt.Fprintf(os.Stderr, "Usage of gqlstruct-import:\n")
+	fmt.Fprintf(os.Stderr, "\tgqlstruct-import -package name [-output file] schema.graphql\n")
+	flag.PrintDefaults()
+}
+
+func main() {
+	flag.Usage = usage
+	flag.Parse()
+	if *packageName == "" || flag.NArg() != 1 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	filename := flag.Arg(0)
+	sdl, err := ioutil.ReadFile(filename)
+	if err != nil {
+		fail(err)
+	}
+
+	imp, err := newImporter(*packageName, filename, string(sdl))
+	if err != nil {
+		fail(err)
+	}
+
+	src, err := imp.generate()
+	if err != nil {
+		fail(err)
+	}
+	for _, warning := range imp.warnings {
+		fmt.Fprintf(os.Stderr, "gqlstruct-import: warning: %s\n", warning)
+	}
+
+	if *output == "" {
+		os.Stdout.Write(src)
+		return
+	}
+	if err := ioutil.WriteFile(*output, src, 0644); err != nil {
+		fail(err)
+	}
+}
+
+func fail(err error) {
+	fmt.Fprintf(os.Stderr, "gqlstruct-import: %s\n", err)
+	os.Exit(1)
+}
@@ -0,0 +1,188 @@
+package gqlstruct
+
+import (
+	"reflect"
+	"strings"
+	"unicode"
+)
+
+// defaultTagKey is the key of the struct tag that describes the fields.
+const defaultTagKey = "graphql"
+
+// encoderConfig is the configuration of an encoder, set by the
+// `EncoderOption`s informed to `NewEncoder`. It is not changed after the
+// encoder is created.
+type encoderConfig struct {
+	tagKey       string
+	jsonFallback bool
+	untagged     bool
+	naming       NamingStrategy
+	typePrefix   string
+	typeSuffix   string
+	// aggregateErrors is set by `WithAggregatedErrors`.
+	aggregateErrors bool
+}
+
+func newEncoderConfig(options ...EncoderOption) encoderConfig {
+	cfg := encoderConfig{
+		tagKey: defaultTagKey,
+		naming: CamelCase,
+	}
+	for _, option := range options {
+		option(&cfg)
+	}
+	return cfg
+}
+
+// EncoderOption configures the encoder created by `NewEncoder`.
+type EncoderOption func(cfg *encoderConfig)
+
+// WithTagKey creates an `EncoderOption` that reads the fields from the tag
+// informed, instead of the "graphql" tag. The format of the tag is the same
+// (check `ParseTag`):
+//
+// ```
+// enc := gqlstruct.NewEncoder(gqlstruct.WithTagKey("gql"))
+// ```
+//
+// An empty key keeps the "graphql" tag.
+func WithTagKey(key string) EncoderOption {
+	return func(cfg *encoderConfig) {
+		if key != "" {
+			cfg.tagKey = key
+		}
+	}
+}
+
+// WithJSONFallback creates an `EncoderOption` that describes the fields
+// without the "graphql" tag by their "json" tag. The name of the field is
+// the name of the "json" tag (or the name given by the `NamingStrategy`, when
+// the "json" tag has no name) and the other options of the "json" tag are
+// ignored. Fields tagged as `json:"-"` are not described.
+func WithJSONFallback() EncoderOption {
+	return func(cfg *encoderConfig) {
+		cfg.jsonFallback = true
+	}
+}
+
+// WithUntaggedFields creates an `EncoderOption` that describes all exported
+// fields, instead of only the tagged ones. The names of the untagged fields
+// are given by the `NamingStrategy` (`CamelCase`, unless `WithNamingStrategy`
+// is informed).
+//
+// Fields can still be omitted by the `graphql:"-"` tag. Untagged embedded
+// structs are not described, as interfaces must be tagged.
+func WithUntaggedFields() EncoderOption {
+	return func(cfg *encoderConfig) {
+		cfg.untagged = true
+	}
+}
+
+// NamingStrategy names the GraphQL field of a struct field that does not
+// have a name in its tags.
+type NamingStrategy func(fieldName string) string
+
+// WithNamingStrategy creates an `EncoderOption` that names the untagged
+// fields (check `WithUntaggedFields` and `WithJSONFallback`) with the naming
+// strategy informed.
+func WithNamingStrategy(naming NamingStrategy) EncoderOption {
+	return func(cfg *encoderConfig) {
+		if naming != nil {
+			cfg.naming = naming
+		}
+	}
+}
+
+// WithTypePrefix creates an `EncoderOption` that prepends the prefix to the
+// names of all types built by the encoder (objects, interfaces, unions,
+// enums and input objects).
+func WithTypePrefix(prefix string) EncoderOption {
+	return func(cfg *encoderConfig) {
+		cfg.typePrefix = prefix
+	}
+}
+
+// WithTypeSuffix creates an `EncoderOption` that appends the suffix to the
+// names of all types built by the encoder. Input objects keep the "Input"
+// suffix after it (`Address` becomes `AddressV2Input`).
+func WithTypeSuffix(suffix string) EncoderOption {
+	return func(cfg *encoderConfig) {
+		cfg.typeSuffix = suffix
+	}
+}
+
+// WithAggregatedErrors creates an `EncoderOption` that builds the types
+// through all their fields, even after an error is found. The fields with
+// errors are skipped and the build fails with `SchemaErrors`, which lists
+// every error along with the path of its field:
+//
+// ```
+// 2 errors found:
+// * Order.items[].product.price (func() int): 'func() int' not recognized
+// * Order.buyer.email (string): User.Email: invalid tag `email,foo`: unknown option 'foo'
+// ```
+//
+// So, a large model can be fixed without rebuilding it once per broken
+// field.
+func WithAggregatedErrors() EncoderOption {
+	return func(cfg *encoderConfig) {
+		cfg.aggregateErrors = true
+	}
+}
+
+// CamelCase is the default `NamingStrategy`. It lowers the first word of the
+// name of the field: "Name" becomes "name", "ID" becomes "id", "UserID"
+// becomes "userID" and "HTMLBody" becomes "htmlBody".
+func CamelCase(fieldName string) string {
+	runes := []rune(fieldName)
+	// upper is the length of the leading upper case run.
+	upper := 0
+	for upper < len(runes) && unicode.IsUpper(runes[upper]) {
+		upper++
+	}
+	if upper > 1 && upper < len(runes) && unicode.IsLower(runes[upper]) {
+		// The last upper case letter starts the next word.
+		upper--
+	}
+	for i := 0; i < upper; i++ {
+		runes[i] = unicode.ToLower(runes[i])
+	}
+	return string(runes)
+}
+
+// fieldTag returns the tag that describes the field, in the format of the
+// "graphql" tag (check `ParseTag`), and whether the field is described.
+func (cfg *encoderConfig) fieldTag(field reflect.StructField) (string, bool) {
+	if tag, ok := field.Tag.Lookup(cfg.tagKey); ok {
+		return tag, tag != "-"
+	}
+
+	// Untagged embedded structs and unexported fields are never described.
+	if field.Anonymous || field.PkgPath != "" {
+		return "", false
+	}
+
+	if cfg.jsonFallback {
+		if tag, ok := field.Tag.Lookup("json"); ok {
+			name := strings.Split(tag, ",")[0]
+			if name == "-" {
+				return "", false
+			}
+			if name != "" {
+				return name, true
+			}
+			return cfg.naming(field.Name), true
+		}
+	}
+
+	if cfg.untagged {
+		return cfg.naming(field.Name), true
+	}
+	return "", false
+}
+
+// typeName returns the name of the GraphQL type built from a Go type with the
+// name informed.
+func (cfg *encoderConfig) typeName(name string) string {
+	return cfg.typePrefix + name + cfg.typeSuffix
+}
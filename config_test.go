@@ -0,0 +1,234 @@
+package gqlstruct_test
+
+import (
+	"github.com/graphql-go/graphql"
+	"github.com/lab259/go-graphql-struct"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"strings"
+)
+
+type ConfigAddress struct {
+	Street string `json:"street"`
+}
+
+type ConfigUser struct {
+	Node      `graphql:",interface"`
+	FullName  string         `json:"full_name"`
+	Email     string         `json:"email,omitempty"`
+	Password  string         `json:"-"`
+	Status    Status         `json:",omitempty"`
+	Address   *ConfigAddress `json:"address"`
+	CreatedAt string
+	internal  string
+}
+
+type ConfigUserArgs struct {
+	Login string `gql:"!login"`
+	Limit int    `gql:"limit,default=10"`
+}
+
+type ConfigQuery struct{}
+
+type ConfigAccount struct {
+	FullName string `gql:"!name"`
+	Mail     string `gql:"mail"`
+}
+
+type ConfigAccountQuery struct {
+	Account *ConfigAccount `gql:"account"`
+}
+
+type ConfigUserQuery struct {
+	User *ConfigUser `json:"user"`
+}
+
+func (q *ConfigQuery) GraphqlMethods() map[string]string {
+	return map[string]string{
+		"Login": "login",
+	}
+}
+
+func (q *ConfigQuery) Login(args ConfigUserArgs) string {
+	return strings.Repeat(args.Login, args.Limit)
+}
+
+var _ = Describe("Encoder options", func() {
+	It("should read the fields from the tag key informed", func() {
+		type Account struct {
+			Name  string `gql:"!name"`
+			Email string `graphql:"email"`
+		}
+
+		enc := gqlstruct.NewEncoder(gqlstruct.WithTagKey("gql"))
+		obj, err := enc.Struct(Account{})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(obj.Fields()).To(HaveLen(1))
+		Expect(obj.Fields()).To(HaveKey("name"))
+		Expect(obj.Fields()["name"].Type.String()).To(Equal("String!"))
+	})
+
+	It("should decode the arguments with the tag key informed", func() {
+		enc := gqlstruct.NewEncoder(gqlstruct.WithTagKey("gql"))
+		schema, err := enc.Schema(&ConfigQuery{}, nil, nil)
+		Expect(err).ToNot(HaveOccurred())
+
+		r := graphql.Do(graphql.Params{
+			Schema:        schema,
+			RequestString: `{ login(login: "ab", limit: 2) }`,
+		})
+		Expect(r.Errors).To(BeEmpty())
+		Expect(r.Data).To(Equal(map[string]interface{}{"login": "abab"}))
+
+		var args ConfigUserArgs
+		Expect(enc.DecodeArgs(map[string]interface{}{"login": "cd"}, &args)).To(Succeed())
+		Expect(args.Login).To(Equal("cd"))
+	})
+
+	It("should resolve the fields read from the tag key informed", func() {
+		enc := gqlstruct.NewEncoder(gqlstruct.WithTagKey("gql"))
+		schema, err := enc.Schema(&ConfigAccountQuery{
+			Account: &ConfigAccount{FullName: "John Doe", Mail: "john@example.com"},
+		}, nil, nil)
+		Expect(err).ToNot(HaveOccurred())
+
+		r := graphql.Do(graphql.Params{
+			Schema:        schema,
+			RequestString: `{ account { name mail } }`,
+		})
+		Expect(r.Errors).To(BeEmpty())
+		Expect(r.Data).To(Equal(map[string]interface{}{
+			"account": map[string]interface{}{"name": "John Doe", "mail": "john@example.com"},
+		}))
+	})
+
+	It("should resolve the fields named by the json tags and the naming strategy", func() {
+		enc := gqlstruct.NewEncoder(gqlstruct.WithJSONFallback(), gqlstruct.WithUntaggedFields(), gqlstruct.WithNamingStrategy(func(name string) string {
+			return "the" + name
+		}))
+		schema, err := enc.Schema(&ConfigUserQuery{
+			User: &ConfigUser{Node: Node{ID: "1"}, FullName: "John Doe", CreatedAt: "2018-01-01"},
+		}, nil, nil)
+		Expect(err).ToNot(HaveOccurred())
+
+		r := graphql.Do(graphql.Params{
+			Schema:        schema,
+			RequestString: `{ user { id full_name theCreatedAt } }`,
+		})
+		Expect(r.Errors).To(BeEmpty())
+		Expect(r.Data).To(Equal(map[string]interface{}{
+			"user": map[string]interface{}{"id": "1", "full_name": "John Doe", "theCreatedAt": "2018-01-01"},
+		}))
+	})
+
+	It("should fall back to the json tags", func() {
+		enc := gqlstruct.NewEncoder(gqlstruct.WithJSONFallback())
+		obj, err := enc.Struct(ConfigUser{})
+		Expect(err).ToNot(HaveOccurred())
+
+		fields := obj.Fields()
+		Expect(fields).To(HaveLen(5))
+		Expect(fields).To(HaveKey("id"))
+		Expect(fields).To(HaveKey("full_name"))
+		Expect(fields).To(HaveKey("email"))
+		Expect(fields).To(HaveKey("status"))
+		Expect(fields).To(HaveKey("address"))
+		Expect(fields["address"].Type.Name()).To(Equal("ConfigAddress"))
+		Expect(fields["address"].Type.(*graphql.Object).Fields()).To(HaveKey("street"))
+	})
+
+	It("should describe the untagged exported fields", func() {
+		enc := gqlstruct.NewEncoder(gqlstruct.WithUntaggedFields())
+		obj, err := enc.Struct(ConfigUser{})
+		Expect(err).ToNot(HaveOccurred())
+
+		fields := obj.Fields()
+		Expect(fields).To(HaveLen(7))
+		for _, name := range []string{"id", "fullName", "email", "password", "status", "address", "createdAt"} {
+			Expect(fields).To(HaveKey(name))
+		}
+	})
+
+	It("should combine the json fallback with the untagged fields", func() {
+		enc := gqlstruct.NewEncoder(gqlstruct.WithJSONFallback(), gqlstruct.WithUntaggedFields())
+		obj, err := enc.Struct(ConfigUser{})
+		Expect(err).ToNot(HaveOccurred())
+
+		fields := obj.Fields()
+		Expect(fields).To(HaveLen(6))
+		Expect(fields).To(HaveKey("full_name"))
+		Expect(fields).To(HaveKey("createdAt"))
+		Expect(fields).ToNot(HaveKey("password"))
+	})
+
+	It("should omit the fields tagged with -", func() {
+		type Account struct {
+			Name   string
+			Secret string `graphql:"-"`
+		}
+
+		enc := gqlstruct.NewEncoder(gqlstruct.WithUntaggedFields())
+		obj, err := enc.Struct(Account{})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(obj.Fields()).To(HaveLen(1))
+		Expect(obj.Fields()).To(HaveKey("name"))
+	})
+
+	It("should name the untagged fields with the naming strategy", func() {
+		type Account struct {
+			FullName string
+		}
+
+		enc := gqlstruct.NewEncoder(gqlstruct.WithUntaggedFields(), gqlstruct.WithNamingStrategy(strings.ToLower))
+		obj, err := enc.Struct(Account{})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(obj.Fields()).To(HaveKey("fullname"))
+	})
+
+	It("should fail when the name from the json tag is not valid", func() {
+		type Account struct {
+			Name string `json:"full-name"`
+		}
+
+		_, err := gqlstruct.NewEncoder(gqlstruct.WithJSONFallback()).Struct(Account{})
+		Expect(err).To(HaveOccurred())
+		Expect(err).To(BeAssignableToTypeOf(&gqlstruct.InvalidTagError{}))
+		Expect(err.Error()).To(Equal("Account.Name: invalid tag `full-name`: 'full-name' is not a valid name"))
+	})
+
+	It("should prefix and suffix the names of the types", func() {
+		type Filter struct {
+			Name string `graphql:"name"`
+		}
+
+		type FilterArgs struct {
+			Filter Filter `graphql:"filter"`
+			Status Status `graphql:"status"`
+		}
+
+		enc := gqlstruct.NewEncoder(gqlstruct.WithTypePrefix("Api"), gqlstruct.WithTypeSuffix("V2"))
+		obj, err := enc.Struct(ConfigUser{})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(obj.Name()).To(Equal("ApiConfigUserV2"))
+		Expect(obj.Interfaces()[0].Name()).To(Equal("ApiNodeV2"))
+
+		args, err := enc.Args(FilterArgs{})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(args["filter"].Type.Name()).To(Equal("ApiFilterV2Input"))
+		Expect(args["status"].Type.Name()).To(Equal("ApiStatusV2"))
+	})
+
+	It("should convert the names of the fields to camel case", func() {
+		names := map[string]string{
+			"Name":     "name",
+			"ID":       "id",
+			"UserID":   "userID",
+			"HTMLBody": "htmlBody",
+			"name":     "name",
+			"":         "",
+		}
+		for name, expected := range names {
+			Expect(gqlstruct.CamelCase(name)).To(Equal(expected), name)
+		}
+	})
+})
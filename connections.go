@@ -0,0 +1,548 @@
+package gqlstruct
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"github.com/graphql-go/graphql"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// cursorPrefix is prepended to the offsets encoded in the cursors.
+const cursorPrefix = "cursor:"
+
+// ConnectionArgs are the arguments of the connections (`first`, `after`,
+// `last` and `before`). The arguments not informed are nil.
+type ConnectionArgs struct {
+	First  *int
+	After  *string
+	Last   *int
+	Before *string
+}
+
+// Connection is a page of a connection. Its nodes must be values of the Go
+// type of the items of the list field.
+type Connection struct {
+	Edges    []Edge
+	PageInfo PageInfo
+}
+
+// Edge is a node of a connection along with its cursor.
+type Edge struct {
+	Node   interface{}
+	Cursor string
+}
+
+// PageInfo tells if there are more pages, before or after the current one.
+// Empty cursors are described as null.
+type PageInfo struct {
+	HasPreviousPage bool
+	HasNextPage     bool
+	StartCursor     string
+	EndCursor       string
+}
+
+// ConnectionResolveFn resolves a page of a connection, for data sources that
+// paginate themselves (check `WithConnectionResolve`).
+type ConnectionResolveFn func(p graphql.ResolveParams, args ConnectionArgs) (*Connection, error)
+
+type withConnection struct {
+	encoder *encoder
+	resolve ConnectionResolveFn
+}
+
+// WithConnection creates a `FieldOption` that describes a list field as a
+// Relay connection, the same as the "connection" option of the tag:
+//
+// ```
+// Orders []Order `graphql:"orders,connection"`
+// ```
+//
+// The `OrderConnection`, `OrderEdge` and `PageInfo` types are built and the
+// `first`, `after`, `last` and `before` arguments are added to the field.
+// The list resolved by the field is paginated in memory (check
+// `PaginateSlice`), unless the resolver returns a `*Connection`.
+//
+// The types are built by the encoder building the field or, when the option
+// is applied directly (`ApplyField`), by the default encoder.
+func WithConnection() FieldOption {
+	return &withConnection{}
+}
+
+// WithConnection creates a `FieldOption` that describes a list field as a
+// Relay connection (check `WithConnection`), with the types built by the
+// encoder.
+func (enc *encoder) WithConnection() FieldOption {
+	return &withConnection{
+		encoder: enc,
+	}
+}
+
+// WithConnectionResolve creates a `FieldOption` that describes a list field
+// as a Relay connection (check `WithConnection`) resolved by the function
+// informed, for data sources that paginate themselves. The function receives
+// the `ConnectionArgs` and returns the page, with its own cursors.
+//
+// It can also be applied to fields already tagged as connections.
+func WithConnectionResolve(resolve ConnectionResolveFn) FieldOption {
+	return &withConnection{
+		resolve: resolve,
+	}
+}
+
+// WithConnectionResolve creates a `FieldOption` that describes a list field
+// as a Relay connection resolved by the function informed (check
+// `WithConnectionResolve`), with the types built by the encoder.
+func (enc *encoder) WithConnectionResolve(resolve ConnectionResolveFn) FieldOption {
+	return &withConnection{
+		encoder: enc,
+		resolve: resolve,
+	}
+}
+
+// ApplyField describes the field as a connection, with the types built by
+// the encoder of the option.
+func (option *withConnection) ApplyField(field *graphql.Field) error {
+	enc := option.encoder
+	if enc == nil {
+		enc = defaultEncoder
+	}
+	defer enc.lock()()
+	return enc.endBuild(enc.connectionField(field, option.resolve))
+}
+
+// connectionField describes the list field as a connection. The field may
+// already be a connection built by the encoder, in which case only the
+// resolver is replaced.
+func (enc *encoder) connectionField(field *graphql.Field, resolve ConnectionResolveFn) error {
+	t, nonNull := field.Type, false
+	if n, ok := t.(*graphql.NonNull); ok {
+		t, nonNull = n.OfType, true
+	}
+
+	if obj, ok := t.(*graphql.Object); ok && enc.isConnection(obj) {
+		if resolve != nil {
+			field.Resolve = connectionResolveFn(resolve)
+			if _, ok := enc.connectionLists[field]; ok {
+				enc.connectionLists[field] = nil
+			}
+		}
+		return nil
+	}
+
+	list, ok := t.(*graphql.List)
+	if !ok {
+		return fmt.Errorf("only lists can be connections, not %s", field.Type)
+	}
+
+	conn, err := enc.connectionOf(list.OfType)
+	if err != nil {
+		return err
+	}
+
+	if field.Args == nil {
+		field.Args = graphql.FieldConfigArgument{}
+	}
+	for name, arg := range connectionArgs() {
+		if _, ok := field.Args[name]; ok {
+			return fmt.Errorf("the argument '%s' is already defined", name)
+		}
+		field.Args[name] = arg
+	}
+
+	field.Type = conn
+	if nonNull {
+		field.Type = graphql.NewNonNull(conn)
+	}
+	var listResolve graphql.FieldResolveFn
+	if resolve != nil {
+		field.Resolve = connectionResolveFn(resolve)
+	} else {
+		listResolve = field.Resolve
+		if listResolve == nil {
+			listResolve = graphql.DefaultResolveFn
+		}
+		field.Resolve = connectionResolve(listResolve)
+	}
+	if enc.connectionLists == nil {
+		enc.connectionLists = make(map[*graphql.Field]graphql.FieldResolveFn)
+	}
+	enc.connectionLists[field] = listResolve
+	return nil
+}
+
+// isConnection checks if the object is a connection built by the encoder.
+func (enc *encoder) isConnection(obj *graphql.Object) bool {
+	_, ok := enc.connectionNode(obj)
+	return ok
+}
+
+// connectionNode returns the node type of the connection built by the
+// encoder.
+func (enc *encoder) connectionNode(obj *graphql.Object) (graphql.Type, bool) {
+	for node, conn := range enc.connections {
+		if conn == obj {
+			return node, true
+		}
+	}
+	return nil, false
+}
+
+// connectionOf builds the connection of the node type informed, named after
+// it (`OrderConnection`, with the `OrderEdge` edges).
+func (enc *encoder) connectionOf(node graphql.Type) (*graphql.Object, error) {
+	if conn, ok := enc.connections[node]; ok {
+		return conn, nil
+	}
+
+	pageInfo, err := enc.pageInfo()
+	if err != nil {
+		return nil, err
+	}
+
+	named := node
+	if n, ok := named.(*graphql.NonNull); ok {
+		named = n.OfType
+	}
+	if _, ok := named.(*graphql.List); ok {
+		return nil, fmt.Errorf("connections of lists are not supported")
+	}
+	name := enc.derivedName(named)
+	edge := graphql.NewObject(graphql.ObjectConfig{
+		Name:        name + "Edge",
+		Description: "An edge of " + name + "Connection.",
+		Fields: graphql.Fields{
+			"node": &graphql.Field{
+				Type: node,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					edge, err := sourceEdge(p.Source)
+					if err != nil {
+						return nil, err
+					}
+					return edge.Node, nil
+				},
+			},
+			"cursor": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.String),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					edge, err := sourceEdge(p.Source)
+					if err != nil {
+						return nil, err
+					}
+					return edge.Cursor, nil
+				},
+			},
+		},
+	})
+	if err := enc.registerName(reflect.TypeOf(Edge{}), edge); err != nil {
+		return nil, err
+	}
+
+	conn := graphql.NewObject(graphql.ObjectConfig{
+		Name:        name + "Connection",
+		Description: "A page of " + name + ".",
+		Fields: graphql.Fields{
+			"edges": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(edge))),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					conn, err := sourceConnection(p.Source)
+					if err != nil {
+						return nil, err
+					}
+					return conn.Edges, nil
+				},
+			},
+			"pageInfo": &graphql.Field{
+				Type: graphql.NewNonNull(pageInfo),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					conn, err := sourceConnection(p.Source)
+					if err != nil {
+						return nil, err
+					}
+					return conn.PageInfo, nil
+				},
+			},
+		},
+	})
+	if err := enc.registerName(reflect.TypeOf(Connection{}), conn); err != nil {
+		return nil, err
+	}
+
+	enc.connections[node] = conn
+	enc.onRollback(func() {
+		delete(enc.connections, node)
+	})
+	return conn, nil
+}
+
+// pageInfo returns the `PageInfo` type, shared by all connections of the
+// encoder.
+func (enc *encoder) pageInfo() (*graphql.Object, error) {
+	if enc.pageInfoType != nil {
+		return enc.pageInfoType, nil
+	}
+
+	cursor := func(cursor string) interface{} {
+		if cursor == "" {
+			return nil
+		}
+		return cursor
+	}
+	r := graphql.NewObject(graphql.ObjectConfig{
+		Name:        enc.config.typeName("PageInfo"),
+		Description: "The pagination of a connection.",
+		Fields: graphql.Fields{
+			"hasPreviousPage": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.Boolean),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					pageInfo, err := sourcePageInfo(p.Source)
+					if err != nil {
+						return nil, err
+					}
+					return pageInfo.HasPreviousPage, nil
+				},
+			},
+			"hasNextPage": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.Boolean),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					pageInfo, err := sourcePageInfo(p.Source)
+					if err != nil {
+						return nil, err
+					}
+					return pageInfo.HasNextPage, nil
+				},
+			},
+			"startCursor": &graphql.Field{
+				Type: graphql.String,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					pageInfo, err := sourcePageInfo(p.Source)
+					if err != nil {
+						return nil, err
+					}
+					return cursor(pageInfo.StartCursor), nil
+				},
+			},
+			"endCursor": &graphql.Field{
+				Type: graphql.String,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					pageInfo, err := sourcePageInfo(p.Source)
+					if err != nil {
+						return nil, err
+					}
+					return cursor(pageInfo.EndCursor), nil
+				},
+			},
+		},
+	})
+	if err := enc.registerName(reflect.TypeOf(PageInfo{}), r); err != nil {
+		return nil, err
+	}
+	enc.pageInfoType = r
+	enc.onRollback(func() {
+		enc.pageInfoType = nil
+	})
+	return r, nil
+}
+
+// connectionArgs creates the arguments of the connections.
+func connectionArgs() graphql.FieldConfigArgument {
+	return graphql.FieldConfigArgument{
+		"first": &graphql.ArgumentConfig{
+			Type:        graphql.Int,
+			Description: "Returns the first n nodes.",
+		},
+		"after": &graphql.ArgumentConfig{
+			Type:        graphql.String,
+			Description: "Returns the nodes after the cursor.",
+		},
+		"last": &graphql.ArgumentConfig{
+			Type:        graphql.Int,
+			Description: "Returns the last n nodes.",
+		},
+		"before": &graphql.ArgumentConfig{
+			Type:        graphql.String,
+			Description: "Returns the nodes before the cursor.",
+		},
+	}
+}
+
+// decodeConnectionArgs extracts the `ConnectionArgs` from the arguments
+// received by the resolver.
+func decodeConnectionArgs(args map[string]interface{}) ConnectionArgs {
+	var r ConnectionArgs
+	if first, ok := args["first"].(int); ok {
+		r.First = &first
+	}
+	if after, ok := args["after"].(string); ok {
+		r.After = &after
+	}
+	if last, ok := args["last"].(int); ok {
+		r.Last = &last
+	}
+	if before, ok := args["before"].(string); ok {
+		r.Before = &before
+	}
+	return r
+}
+
+// sourceConnection returns the connection resolved, as a value or a pointer.
+func sourceConnection(source interface{}) (*Connection, error) {
+	switch conn := source.(type) {
+	case *Connection:
+		if conn != nil {
+			return conn, nil
+		}
+	case Connection:
+		return &conn, nil
+	}
+	return nil, fmt.Errorf("cannot resolve %T as a connection", source)
+}
+
+// sourceEdge returns the edge resolved, as a value or a pointer.
+func sourceEdge(source interface{}) (Edge, error) {
+	switch edge := source.(type) {
+	case Edge:
+		return edge, nil
+	case *Edge:
+		if edge != nil {
+			return *edge, nil
+		}
+	}
+	return Edge{}, fmt.Errorf("cannot resolve %T as an edge", source)
+}
+
+// sourcePageInfo returns the page info resolved, as a value or a pointer.
+func sourcePageInfo(source interface{}) (PageInfo, error) {
+	switch pageInfo := source.(type) {
+	case PageInfo:
+		return pageInfo, nil
+	case *PageInfo:
+		if pageInfo != nil {
+			return *pageInfo, nil
+		}
+	}
+	return PageInfo{}, fmt.Errorf("cannot resolve %T as a page info", source)
+}
+
+// connectionResolve creates a resolver that paginates the list resolved by
+// resolve. Connections resolved are returned as they are.
+func connectionResolve(resolve graphql.FieldResolveFn) graphql.FieldResolveFn {
+	if resolve == nil {
+		resolve = graphql.DefaultResolveFn
+	}
+	return func(p graphql.ResolveParams) (interface{}, error) {
+		value, err := resolve(p)
+		if err != nil || value == nil {
+			return nil, err
+		}
+		switch conn := value.(type) {
+		case *Connection:
+			return conn, nil
+		case Connection:
+			return &conn, nil
+		}
+		return PaginateSlice(value, decodeConnectionArgs(p.Args))
+	}
+}
+
+// connectionResolveFn creates a resolver that calls the `ConnectionResolveFn`.
+func connectionResolveFn(resolve ConnectionResolveFn) graphql.FieldResolveFn {
+	return func(p graphql.ResolveParams) (interface{}, error) {
+		conn, err := resolve(p, decodeConnectionArgs(p.Args))
+		if err != nil || conn == nil {
+			return nil, err
+		}
+		return conn, nil
+	}
+}
+
+// PaginateSlice returns the page of the slice (or array) selected by the
+// arguments, as described by the Relay specification. The cursors are the
+// offsets of the items in the slice (check `EncodeCursor`).
+func PaginateSlice(slice interface{}, args ConnectionArgs) (*Connection, error) {
+	v := reflect.ValueOf(slice)
+	if v.Kind() == reflect.Ptr {
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Slice && v.Kind() != reflect.Array {
+		return nil, fmt.Errorf("cannot paginate %T, it is not a slice", slice)
+	}
+
+	start, end := 0, v.Len()
+	if args.After != nil {
+		after, err := DecodeCursor(*args.After)
+		if err != nil {
+			return nil, err
+		}
+		if after+1 > start {
+			start = after + 1
+		}
+	}
+	if args.Before != nil {
+		before, err := DecodeCursor(*args.Before)
+		if err != nil {
+			return nil, err
+		}
+		if before < end {
+			end = before
+		}
+	}
+	if args.First != nil {
+		if *args.First < 0 {
+			return nil, errors.New("first cannot be negative")
+		}
+		if start+*args.First < end {
+			end = start + *args.First
+		}
+	}
+	if args.Last != nil {
+		if *args.Last < 0 {
+			return nil, errors.New("last cannot be negative")
+		}
+		if end-*args.Last > start {
+			start = end - *args.Last
+		}
+	}
+	if start > end {
+		start = end
+	}
+
+	r := &Connection{
+		Edges: make([]Edge, 0, end-start),
+		PageInfo: PageInfo{
+			HasPreviousPage: start > 0,
+			HasNextPage:     end < v.Len(),
+		},
+	}
+	for i := start; i < end; i++ {
+		r.Edges = append(r.Edges, Edge{
+			Node:   v.Index(i).Interface(),
+			Cursor: EncodeCursor(i),
+		})
+	}
+	if len(r.Edges) > 0 {
+		r.PageInfo.StartCursor = r.Edges[0].Cursor
+		r.PageInfo.EndCursor = r.Edges[len(r.Edges)-1].Cursor
+	}
+	return r, nil
+}
+
+// EncodeCursor creates the opaque cursor of the offset.
+func EncodeCursor(offset int) string {
+	return base64.StdEncoding.EncodeToString([]byte(cursorPrefix + strconv.Itoa(offset)))
+}
+
+// DecodeCursor returns the offset of a cursor created by `EncodeCursor`.
+func DecodeCursor(cursor string) (int, error) {
+	data, err := base64.StdEncoding.DecodeString(cursor)
+	if err != nil || !strings.HasPrefix(string(data), cursorPrefix) {
+		return 0, fmt.Errorf("invalid cursor '%s'", cursor)
+	}
+	offset, err := strconv.Atoi(strings.TrimPrefix(string(data), cursorPrefix))
+	if err != nil || offset < 0 {
+		return 0, fmt.Errorf("invalid cursor '%s'", cursor)
+	}
+	return offset, nil
+}
@@ -0,0 +1,338 @@
+package gqlstruct_test
+
+import (
+	"github.com/graphql-go/graphql"
+	"github.com/lab259/go-graphql-struct"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+type ConnOrder struct {
+	ID string `graphql:"id"`
+}
+
+type ConnTagsArgs struct {
+	Prefix string `graphql:"prefix"`
+}
+
+type ConnUser struct {
+	Orders []ConnOrder `graphql:"!orders,connection"`
+	Names  []string    `graphql:"names"`
+	tags   []string
+}
+
+func (u *ConnUser) GraphqlMethods() map[string]string {
+	return map[string]string{
+		"Tags": "tags,connection",
+	}
+}
+
+func (u *ConnUser) Tags(args ConnTagsArgs) []string {
+	r := make([]string, len(u.tags))
+	for i, tag := range u.tags {
+		r[i] = args.Prefix + tag
+	}
+	return r
+}
+
+type ConnQuery struct {
+	User ConnUser `graphql:"user"`
+}
+
+func intPtr(i int) *int {
+	return &i
+}
+
+func stringPtr(s string) *string {
+	return &s
+}
+
+var _ = Describe("Connections", func() {
+	newSchema := func(options ...gqlstruct.StructOption) graphql.Schema {
+		enc := gqlstruct.NewEncoder()
+		_, err := enc.Struct(ConnUser{}, options...)
+		Expect(err).ToNot(HaveOccurred())
+
+		schema, err := enc.Schema(&ConnQuery{
+			User: ConnUser{
+				Orders: []ConnOrder{{ID: "1"}, {ID: "2"}, {ID: "3"}, {ID: "4"}},
+				Names:  []string{"a", "b", "c"},
+				tags:   []string{"x", "y"},
+			},
+		}, nil, nil)
+		Expect(err).ToNot(HaveOccurred())
+		return schema
+	}
+
+	It("should build the connection types of the fields tagged", func() {
+		enc := gqlstruct.NewEncoder()
+		obj, err := enc.Struct(ConnUser{})
+		Expect(err).ToNot(HaveOccurred())
+
+		orders := obj.Fields()["orders"]
+		Expect(orders.Type.String()).To(Equal("ConnOrderConnection!"))
+		Expect(orders.Args).To(HaveLen(4))
+		Expect(obj.Fields()["tags"].Type.String()).To(Equal("StringConnection"))
+		Expect(obj.Fields()["tags"].Args).To(HaveLen(5))
+
+		Expect(enc.PrintSDL()).To(ContainSubstring(`type ConnOrderConnection {
+  edges: [ConnOrderEdge!]!
+  pageInfo: PageInfo!
+}`))
+		Expect(enc.PrintSDL()).To(ContainSubstring(`type PageInfo {
+  endCursor: String
+  hasNextPage: Boolean!
+  hasPreviousPage: Boolean!
+  startCursor: String
+}`))
+	})
+
+	It("should paginate the lists forward", func() {
+		schema := newSchema()
+		r := graphql.Do(graphql.Params{
+			Schema:        schema,
+			RequestString: `{ user { orders(first: 2) { edges { cursor node { id } } pageInfo { hasNextPage hasPreviousPage endCursor } } } }`,
+		})
+		Expect(r.Errors).To(BeEmpty())
+		orders := r.Data.(map[string]interface{})["user"].(map[string]interface{})["orders"].(map[string]interface{})
+		Expect(orders["edges"]).To(Equal([]interface{}{
+			map[string]interface{}{"cursor": gqlstruct.EncodeCursor(0), "node": map[string]interface{}{"id": "1"}},
+			map[string]interface{}{"cursor": gqlstruct.EncodeCursor(1), "node": map[string]interface{}{"id": "2"}},
+		}))
+		Expect(orders["pageInfo"]).To(Equal(map[string]interface{}{
+			"hasNextPage":     true,
+			"hasPreviousPage": false,
+			"endCursor":       gqlstruct.EncodeCursor(1),
+		}))
+
+		r = graphql.Do(graphql.Params{
+			Schema:        schema,
+			RequestString: `{ user { orders(first: 5, after: "` + gqlstruct.EncodeCursor(1) + `") { edges { node { id } } pageInfo { hasNextPage hasPreviousPage } } } }`,
+		})
+		Expect(r.Errors).To(BeEmpty())
+		orders = r.Data.(map[string]interface{})["user"].(map[string]interface{})["orders"].(map[string]interface{})
+		Expect(orders["edges"]).To(HaveLen(2))
+		Expect(orders["pageInfo"]).To(Equal(map[string]interface{}{
+			"hasNextPage":     false,
+			"hasPreviousPage": true,
+		}))
+	})
+
+	It("should paginate the results of the methods", func() {
+		r := graphql.Do(graphql.Params{
+			Schema:        newSchema(),
+			RequestString: `{ user { tags(prefix: "#", last: 1) { edges { node } } } }`,
+		})
+		Expect(r.Errors).To(BeEmpty())
+		Expect(r.Data).To(Equal(map[string]interface{}{
+			"user": map[string]interface{}{
+				"tags": map[string]interface{}{
+					"edges": []interface{}{
+						map[string]interface{}{"node": "#y"},
+					},
+				},
+			},
+		}))
+	})
+
+	It("should describe the fields as connections by the option", func() {
+		r := graphql.Do(graphql.Params{
+			Schema:        newSchema(gqlstruct.WithFieldOptions("names", gqlstruct.WithConnection())),
+			RequestString: `{ user { names(before: "` + gqlstruct.EncodeCursor(2) + `", last: 1) { edges { node } } } }`,
+		})
+		Expect(r.Errors).To(BeEmpty())
+		Expect(r.Data).To(Equal(map[string]interface{}{
+			"user": map[string]interface{}{
+				"names": map[string]interface{}{
+					"edges": []interface{}{
+						map[string]interface{}{"node": "b"},
+					},
+				},
+			},
+		}))
+	})
+
+	It("should resolve the connections of data sources that paginate themselves", func() {
+		var received gqlstruct.ConnectionArgs
+		schema := newSchema(gqlstruct.WithFieldOptions("orders", gqlstruct.WithConnectionResolve(
+			func(p graphql.ResolveParams, args gqlstruct.ConnectionArgs) (*gqlstruct.Connection, error) {
+				received = args
+				return &gqlstruct.Connection{
+					Edges: []gqlstruct.Edge{
+						{Node: ConnOrder{ID: "10"}, Cursor: "page-2"},
+					},
+					PageInfo: gqlstruct.PageInfo{HasNextPage: true, EndCursor: "page-2"},
+				}, nil
+			},
+		)))
+
+		r := graphql.Do(graphql.Params{
+			Schema:        schema,
+			RequestString: `{ user { orders(first: 1, after: "page-1") { edges { cursor node { id } } pageInfo { hasNextPage startCursor endCursor } } } }`,
+		})
+		Expect(r.Errors).To(BeEmpty())
+		Expect(received).To(Equal(gqlstruct.ConnectionArgs{First: intPtr(1), After: stringPtr("page-1")}))
+		Expect(r.Data).To(Equal(map[string]interface{}{
+			"user": map[string]interface{}{
+				"orders": map[string]interface{}{
+					"edges": []interface{}{
+						map[string]interface{}{"cursor": "page-2", "node": map[string]interface{}{"id": "10"}},
+					},
+					"pageInfo": map[string]interface{}{
+						"hasNextPage": true,
+						"startCursor": nil,
+						"endCursor":   "page-2",
+					},
+				},
+			},
+		}))
+	})
+
+	It("should resolve the connections returned as values", func() {
+		schema := newSchema(gqlstruct.WithFieldOptions("names",
+			gqlstruct.WithConnection(),
+			gqlstruct.WithResolve(func(p graphql.ResolveParams) (interface{}, error) {
+				return gqlstruct.Connection{
+					Edges:    []gqlstruct.Edge{{Node: "z", Cursor: "c"}},
+					PageInfo: gqlstruct.PageInfo{HasNextPage: true},
+				}, nil
+			}),
+		))
+
+		r := graphql.Do(graphql.Params{
+			Schema:        schema,
+			RequestString: `{ user { names { edges { cursor node } pageInfo { hasNextPage } } } }`,
+		})
+		Expect(r.Errors).To(BeEmpty())
+		Expect(r.Data).To(Equal(map[string]interface{}{
+			"user": map[string]interface{}{
+				"names": map[string]interface{}{
+					"edges": []interface{}{
+						map[string]interface{}{"cursor": "c", "node": "z"},
+					},
+					"pageInfo": map[string]interface{}{"hasNextPage": true},
+				},
+			},
+		}))
+	})
+
+	It("should fail when the value resolved is not a connection", func() {
+		schema := newSchema(gqlstruct.WithFieldOptions("names",
+			gqlstruct.WithConnection(),
+			gqlstruct.WithResolve(func(p graphql.ResolveParams) (interface{}, error) {
+				return []string{"a"}, nil
+			}),
+		))
+
+		r := graphql.Do(graphql.Params{
+			Schema:        schema,
+			RequestString: `{ user { names { edges { node } } } }`,
+		})
+		Expect(r.Errors).To(HaveLen(1))
+		Expect(r.Errors[0].Message).To(Equal("cannot resolve []string as a connection"))
+	})
+
+	It("should build the types by the encoder of the option", func() {
+		enc := gqlstruct.NewEncoder(gqlstruct.WithTypePrefix("Api"))
+		field := &graphql.Field{Type: graphql.NewList(graphql.String)}
+		Expect(enc.WithConnection().ApplyField(field)).To(Succeed())
+		Expect(field.Type.Name()).To(Equal("ApiStringConnection"))
+	})
+
+	It("should share the connection types of the same nodes", func() {
+		type Group struct {
+			Orders []ConnOrder `graphql:"orders,connection"`
+		}
+
+		enc := gqlstruct.NewEncoder()
+		user, err := enc.Struct(ConnUser{})
+		Expect(err).ToNot(HaveOccurred())
+		group, err := enc.Struct(Group{})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(group.Fields()["orders"].Type).To(BeIdenticalTo(user.Fields()["orders"].Type.(*graphql.NonNull).OfType))
+	})
+
+	It("should fail when the field is not a list", func() {
+		type Account struct {
+			Name string `graphql:"name,connection"`
+		}
+
+		_, err := gqlstruct.NewEncoder().Struct(Account{})
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(Equal("Account.Name: only lists can be connections, not String"))
+	})
+
+	It("should fail when the arguments are already defined", func() {
+		type Args struct {
+			First int `graphql:"first"`
+		}
+
+		type Account struct {
+			Names []string `graphql:"names"`
+		}
+
+		_, err := gqlstruct.NewEncoder().Struct(Account{}, gqlstruct.WithFieldOptions("names", gqlstruct.WithArgs(Args{}), gqlstruct.WithConnection()))
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(Equal("Account.names: the argument 'first' is already defined"))
+	})
+
+	Describe("PaginateSlice", func() {
+		items := []int{0, 1, 2, 3, 4}
+
+		nodes := func(conn *gqlstruct.Connection) []interface{} {
+			r := make([]interface{}, len(conn.Edges))
+			for i, edge := range conn.Edges {
+				r[i] = edge.Node
+			}
+			return r
+		}
+
+		It("should return all items without arguments", func() {
+			conn, err := gqlstruct.PaginateSlice(items, gqlstruct.ConnectionArgs{})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(nodes(conn)).To(Equal([]interface{}{0, 1, 2, 3, 4}))
+			Expect(conn.PageInfo).To(Equal(gqlstruct.PageInfo{
+				StartCursor: gqlstruct.EncodeCursor(0),
+				EndCursor:   gqlstruct.EncodeCursor(4),
+			}))
+		})
+
+		It("should paginate between the cursors", func() {
+			conn, err := gqlstruct.PaginateSlice(items, gqlstruct.ConnectionArgs{
+				After:  stringPtr(gqlstruct.EncodeCursor(0)),
+				Before: stringPtr(gqlstruct.EncodeCursor(4)),
+				Last:   intPtr(2),
+			})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(nodes(conn)).To(Equal([]interface{}{2, 3}))
+			Expect(conn.PageInfo.HasPreviousPage).To(BeTrue())
+			Expect(conn.PageInfo.HasNextPage).To(BeTrue())
+		})
+
+		It("should return an empty page after the last item", func() {
+			conn, err := gqlstruct.PaginateSlice(items, gqlstruct.ConnectionArgs{
+				After: stringPtr(gqlstruct.EncodeCursor(10)),
+			})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(conn.Edges).To(BeEmpty())
+			Expect(conn.PageInfo).To(Equal(gqlstruct.PageInfo{HasPreviousPage: true}))
+		})
+
+		It("should fail with invalid arguments", func() {
+			_, err := gqlstruct.PaginateSlice(items, gqlstruct.ConnectionArgs{First: intPtr(-1)})
+			Expect(err).To(MatchError("first cannot be negative"))
+
+			_, err = gqlstruct.PaginateSlice(items, gqlstruct.ConnectionArgs{After: stringPtr("1")})
+			Expect(err).To(MatchError("invalid cursor '1'"))
+
+			_, err = gqlstruct.PaginateSlice(1, gqlstruct.ConnectionArgs{})
+			Expect(err).To(MatchError("cannot paginate int, it is not a slice"))
+		})
+
+		It("should decode the cursors encoded", func() {
+			offset, err := gqlstruct.DecodeCursor(gqlstruct.EncodeCursor(42))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(offset).To(Equal(42))
+		})
+	})
+})
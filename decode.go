@@ -0,0 +1,226 @@
+package gqlstruct
+
+import (
+	"encoding"
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"time"
+)
+
+// GraphqlDecoder is the interface implemented by custom types that decode
+// the values parsed by the `graphql.Type` they provide (check `GraphqlTyped`).
+type GraphqlDecoder interface {
+	// GraphqlDecode sets the value parsed by graphql-go to the receiver.
+	GraphqlDecode(value interface{}) error
+}
+
+var (
+	graphqlDecoderType  = reflect.TypeOf(new(GraphqlDecoder)).Elem()
+	textUnmarshalerType = reflect.TypeOf(new(encoding.TextUnmarshaler)).Elem()
+)
+
+// DecodeArgs fills the struct pointed by dst with the arguments received by a
+// resolver (`p.Args`). It is the inverse of `ArgsOf`, matching the arguments
+// by the names defined in the "graphql" tags.
+//
+// Nested input objects, lists, pointers and `time.Time` are supported. Custom
+// types can implement `GraphqlDecoder` (or `encoding.TextUnmarshaler`, for
+// string values) to decode the value parsed by their `graphql.Type`.
+//
+// When a value does not match the type of the field, or the field is not
+// exported, a `*DecodeError` with the path of the argument is returned.
+func DecodeArgs(args map[string]interface{}, dst interface{}) error {
+	return defaultEncoder.DecodeArgs(args, dst)
+}
+
+// DecodeArgs fills the struct pointed by dst with the arguments received by
+// a resolver, matching the fields the same way the encoder describes them
+// (check `NewEncoder`).
+func (enc *encoder) DecodeArgs(args map[string]interface{}, dst interface{}) error {
+	v := reflect.ValueOf(dst)
+	if v.Kind() != reflect.Ptr || v.IsNil() || v.Elem().Kind() != reflect.Struct {
+		return errors.New("the destination must be a pointer to a struct")
+	}
+	return enc.config.decodeArgs(args, v.Elem(), "")
+}
+
+// decodeArgs fills the struct dst with the values of args, matching the
+// names of the tags of its fields.
+func (cfg *encoderConfig) decodeArgs(args map[string]interface{}, dst reflect.Value, path string) error {
+	t := dst.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag, ok := cfg.fieldTag(field)
+		if !ok {
+			continue
+		}
+
+		info, err := ParseTag(tag)
+		if err != nil {
+			return newErrInvalidTag(err, t, field, tag)
+		}
+
+		if info.Interface {
+			// The fields of the embedded interfaces are promoted.
+			embedded := dst.Field(i)
+			if embedded.Kind() == reflect.Ptr {
+				if embedded.IsNil() {
+					if !embedded.CanSet() {
+						// Unexported pointers cannot be allocated, so their
+						// fields are not decoded.
+						continue
+					}
+					embedded.Set(reflect.New(embedded.Type().Elem()))
+				}
+				embedded = embedded.Elem()
+			}
+			if err := cfg.decodeArgs(args, embedded, path); err != nil {
+				return err
+			}
+			continue
+		}
+
+		value, ok := args[info.Name]
+		if !ok {
+			continue
+		}
+
+		fieldPath := info.Name
+		if path != "" {
+			fieldPath = path + "." + info.Name
+		}
+		if !dst.Field(i).CanSet() {
+			return NewErrDecode(fieldPath, value, field.Type, fmt.Errorf("%s.%s is not exported", t.Name(), field.Name))
+		}
+		if err := cfg.decodeValue(value, dst.Field(i), fieldPath); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// decodeValue sets the value, as provided by graphql-go, to dst.
+func (cfg *encoderConfig) decodeValue(value interface{}, dst reflect.Value, path string) error {
+	if value == nil {
+		dst.Set(reflect.Zero(dst.Type()))
+		return nil
+	}
+
+	if dst.Kind() != reflect.Ptr && reflect.PtrTo(dst.Type()).Implements(graphqlDecoderType) {
+		if err := dst.Addr().Interface().(GraphqlDecoder).GraphqlDecode(value); err != nil {
+			return NewErrDecode(path, value, dst.Type(), err)
+		}
+		return nil
+	}
+
+	v := reflect.ValueOf(value)
+	if v.Type().AssignableTo(dst.Type()) {
+		dst.Set(v)
+		return nil
+	}
+
+	switch dst.Kind() {
+	case reflect.Ptr:
+		elem := reflect.New(dst.Type().Elem())
+		if err := cfg.decodeValue(value, elem.Elem(), path); err != nil {
+			return err
+		}
+		dst.Set(elem)
+		return nil
+	case reflect.Struct:
+		if s, ok := value.(string); ok {
+			if dst.Type() == timeType {
+				tm, err := time.Parse(time.RFC3339, s)
+				if err != nil {
+					return NewErrDecode(path, value, dst.Type(), err)
+				}
+				dst.Set(reflect.ValueOf(tm))
+				return nil
+			}
+			if reflect.PtrTo(dst.Type()).Implements(textUnmarshalerType) {
+				if err := dst.Addr().Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(s)); err != nil {
+					return NewErrDecode(path, value, dst.Type(), err)
+				}
+				return nil
+			}
+		}
+		if m, ok := value.(map[string]interface{}); ok {
+			return cfg.decodeArgs(m, dst, path)
+		}
+	case reflect.Slice:
+		if v.Kind() == reflect.Slice {
+			s := reflect.MakeSlice(dst.Type(), v.Len(), v.Len())
+			for i := 0; i < v.Len(); i++ {
+				if err := cfg.decodeValue(v.Index(i).Interface(), s.Index(i), fmt.Sprintf("%s[%d]", path, i)); err != nil {
+					return err
+				}
+			}
+			dst.Set(s)
+			return nil
+		}
+	default:
+		if s, ok := value.(string); ok && reflect.PtrTo(dst.Type()).Implements(textUnmarshalerType) {
+			if err := dst.Addr().Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(s)); err != nil {
+				return NewErrDecode(path, value, dst.Type(), err)
+			}
+			return nil
+		}
+		if kindClass(v.Kind()) == kindClass(dst.Kind()) && kindClass(v.Kind()) != "" {
+			if err := checkConversion(v, dst.Type()); err != nil {
+				return NewErrDecode(path, value, dst.Type(), err)
+			}
+			dst.Set(v.Convert(dst.Type()))
+			return nil
+		}
+	}
+	return NewErrDecode(path, value, dst.Type(), nil)
+}
+
+func kindClass(kind reflect.Kind) string {
+	switch kind {
+	case reflect.Bool:
+		return "bool"
+	case reflect.String:
+		return "string"
+	case
+		reflect.Int, reflect.Int64, reflect.Int32, reflect.Int16, reflect.Int8,
+		reflect.Uint, reflect.Uint64, reflect.Uint32, reflect.Uint16, reflect.Uint8,
+		reflect.Float32, reflect.Float64:
+		return "number"
+	}
+	return ""
+}
+
+// checkConversion checks if the number v fits into the type t.
+func checkConversion(v reflect.Value, t reflect.Type) error {
+	var f float64
+	switch v.Kind() {
+	case reflect.Int, reflect.Int64, reflect.Int32, reflect.Int16, reflect.Int8:
+		f = float64(v.Int())
+	case reflect.Uint, reflect.Uint64, reflect.Uint32, reflect.Uint16, reflect.Uint8:
+		f = float64(v.Uint())
+	case reflect.Float32, reflect.Float64:
+		f = v.Float()
+	default:
+		return nil
+	}
+
+	dst := reflect.New(t).Elem()
+	switch t.Kind() {
+	case reflect.Int, reflect.Int64, reflect.Int32, reflect.Int16, reflect.Int8:
+		if f != math.Trunc(f) || dst.OverflowInt(int64(f)) {
+			return errors.New("out of range")
+		}
+	case reflect.Uint, reflect.Uint64, reflect.Uint32, reflect.Uint16, reflect.Uint8:
+		if f != math.Trunc(f) || f < 0 || dst.OverflowUint(uint64(f)) {
+			return errors.New("out of range")
+		}
+	case reflect.Float32:
+		if dst.OverflowFloat(f) {
+			return errors.New("out of range")
+		}
+	}
+	return nil
+}
@@ -0,0 +1,234 @@
+package gqlstruct_test
+
+import (
+	"errors"
+	"github.com/graphql-go/graphql"
+	"github.com/lab259/go-graphql-struct"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"reflect"
+	"strings"
+	"time"
+)
+
+type DecodeCode struct {
+	Value string
+}
+
+func (c *DecodeCode) GraphqlType() graphql.Type {
+	return graphql.String
+}
+
+func (c *DecodeCode) GraphqlDecode(value interface{}) error {
+	s, ok := value.(string)
+	if !ok || !strings.HasPrefix(s, "#") {
+		return errors.New("invalid code")
+	}
+	c.Value = s[1:]
+	return nil
+}
+
+type DecodeEmail string
+
+func (e *DecodeEmail) UnmarshalText(text []byte) error {
+	*e = DecodeEmail(strings.ToLower(string(text)))
+	return nil
+}
+
+var _ = Describe("DecodeArgs", func() {
+	type Address struct {
+		Street string `graphql:"!street"`
+		Number *int   `graphql:"number"`
+	}
+
+	type Args struct {
+		Node      `graphql:",interface"`
+		Name      string      `graphql:"!name"`
+		Age       int8        `graphql:"age"`
+		Score     float32     `graphql:"score"`
+		Active    *bool       `graphql:"active"`
+		Tags      []string    `graphql:"tags"`
+		Status    Status      `graphql:"status"`
+		Statuses  []Status    `graphql:"statuses"`
+		Address   *Address    `graphql:"address"`
+		Addresses []Address   `graphql:"addresses"`
+		BirthDate time.Time   `graphql:"birthDate"`
+		Code      DecodeCode  `graphql:"code"`
+		Email     DecodeEmail `graphql:"email"`
+		Ignored   string
+	}
+
+	It("should decode the arguments", func() {
+		var args Args
+		number := 42
+		birthDate := time.Date(2000, 1, 2, 3, 4, 5, 0, time.UTC)
+		err := gqlstruct.DecodeArgs(map[string]interface{}{
+			"id":       "1",
+			"name":     "John",
+			"age":      30,
+			"score":    9.5,
+			"active":   true,
+			"tags":     []interface{}{"a", "b"},
+			"status":   StatusBlocked,
+			"statuses": []interface{}{StatusActive, StatusRemoved},
+			"address": map[string]interface{}{
+				"street": "Main St",
+				"number": number,
+			},
+			"addresses": []interface{}{
+				map[string]interface{}{"street": "First St"},
+			},
+			"birthDate": birthDate,
+			"code":      "#abc",
+			"email":     "John@Doe.COM",
+			"Ignored":   "value",
+		}, &args)
+		Expect(err).ToNot(HaveOccurred())
+		active := true
+		Expect(args).To(Equal(Args{
+			Node:      Node{ID: "1"},
+			Name:      "John",
+			Age:       30,
+			Score:     9.5,
+			Active:    &active,
+			Tags:      []string{"a", "b"},
+			Status:    StatusBlocked,
+			Statuses:  []Status{StatusActive, StatusRemoved},
+			Address:   &Address{Street: "Main St", Number: &number},
+			Addresses: []Address{{Street: "First St"}},
+			BirthDate: birthDate,
+			Code:      DecodeCode{Value: "abc"},
+			Email:     "john@doe.com",
+		}))
+	})
+
+	It("should decode dates from strings", func() {
+		var args Args
+		err := gqlstruct.DecodeArgs(map[string]interface{}{
+			"birthDate": "2000-01-02T03:04:05Z",
+		}, &args)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(args.BirthDate).To(Equal(time.Date(2000, 1, 2, 3, 4, 5, 0, time.UTC)))
+	})
+
+	It("should set nil values", func() {
+		active := true
+		args := Args{Name: "John", Active: &active}
+		err := gqlstruct.DecodeArgs(map[string]interface{}{
+			"active": nil,
+		}, &args)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(args.Name).To(Equal("John"))
+		Expect(args.Active).To(BeNil())
+	})
+
+	It("should decode the values parsed by graphql-go", func() {
+		enc := gqlstruct.NewEncoder()
+		argsConfig, err := enc.ArgsOf(reflect.TypeOf(Args{}))
+		Expect(err).ToNot(HaveOccurred())
+
+		var args Args
+		schema, err := graphql.NewSchema(graphql.SchemaConfig{
+			Query: graphql.NewObject(graphql.ObjectConfig{
+				Name: "Query",
+				Fields: graphql.Fields{
+					"decode": &graphql.Field{
+						Type: graphql.Boolean,
+						Args: argsConfig,
+						Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+							return true, gqlstruct.DecodeArgs(p.Args, &args)
+						},
+					},
+				},
+			}),
+		})
+		Expect(err).ToNot(HaveOccurred())
+
+		r := graphql.Do(graphql.Params{
+			Schema: schema,
+			RequestString: `{
+				decode(
+					id: "1", name: "John", age: 30, score: 1.5, tags: ["a"], status: REMOVED,
+					addresses: [{ street: "First St", number: 1 }],
+					birthDate: "2000-01-02T03:04:05Z", code: "#abc"
+				)
+			}`,
+		})
+		Expect(r.Errors).To(BeEmpty())
+		number := 1
+		Expect(args).To(Equal(Args{
+			Node:      Node{ID: "1"},
+			Name:      "John",
+			Age:       30,
+			Score:     1.5,
+			Tags:      []string{"a"},
+			Status:    StatusRemoved,
+			Addresses: []Address{{Street: "First St", Number: &number}},
+			BirthDate: time.Date(2000, 1, 2, 3, 4, 5, 0, time.UTC),
+			Code:      DecodeCode{Value: "abc"},
+		}))
+	})
+
+	It("should report type mismatches with the path", func() {
+		var args Args
+		err := gqlstruct.DecodeArgs(map[string]interface{}{
+			"addresses": []interface{}{
+				map[string]interface{}{"street": "First St"},
+				map[string]interface{}{"street": 10},
+			},
+		}, &args)
+		Expect(err).To(HaveOccurred())
+		Expect(err).To(BeAssignableToTypeOf(&gqlstruct.DecodeError{}))
+		Expect(err.Error()).To(Equal("addresses[1].street: cannot use 10 (int) as string"))
+	})
+
+	It("should report numbers out of range", func() {
+		var args Args
+		err := gqlstruct.DecodeArgs(map[string]interface{}{
+			"age": 300,
+		}, &args)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(Equal("age: cannot use 300 (int) as int8: out of range"))
+	})
+
+	It("should report the errors of custom types", func() {
+		var args Args
+		err := gqlstruct.DecodeArgs(map[string]interface{}{
+			"code": "abc",
+		}, &args)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(Equal("code: cannot use abc (string) as gqlstruct_test.DecodeCode: invalid code"))
+	})
+
+	It("should report invalid dates", func() {
+		var args Args
+		err := gqlstruct.DecodeArgs(map[string]interface{}{
+			"birthDate": "yesterday",
+		}, &args)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("birthDate: cannot use yesterday (string) as time.Time"))
+	})
+
+	It("should report the arguments of unexported fields", func() {
+		type PageArgs struct {
+			Limit  int `graphql:"limit"`
+			offset int `graphql:"offset"`
+		}
+
+		var args PageArgs
+		Expect(gqlstruct.DecodeArgs(map[string]interface{}{"limit": 10}, &args)).To(Succeed())
+		Expect(args.Limit).To(Equal(10))
+
+		err := gqlstruct.DecodeArgs(map[string]interface{}{"limit": 10, "offset": 20}, &args)
+		Expect(err).To(HaveOccurred())
+		Expect(err).To(BeAssignableToTypeOf(&gqlstruct.DecodeError{}))
+		Expect(err.Error()).To(Equal("offset: cannot use 20 (int) as int: PageArgs.offset is not exported"))
+		Expect(args.offset).To(BeZero())
+	})
+
+	It("should fail when the destination is not a pointer to a struct", func() {
+		err := gqlstruct.DecodeArgs(map[string]interface{}{}, Args{})
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("must be a pointer to a struct"))
+	})
+})
@@ -0,0 +1,495 @@
+package diff
+
+import (
+	"fmt"
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/language/ast"
+	"github.com/graphql-go/graphql/language/parser"
+	"github.com/graphql-go/graphql/language/printer"
+	"sort"
+)
+
+// schema is the normalized representation of a SDL document that is
+// compared.
+type schema struct {
+	types map[string]*typeDef
+	// roots maps the operations (query, mutation and subscription) to the
+	// name of their root types.
+	roots map[string]string
+}
+
+// typeDef is a named type of a schema. Only the members that make sense for
+// its kind are filled.
+type typeDef struct {
+	kind        string
+	description string
+	fields      map[string]*fieldDef
+	inputFields map[string]*inputDef
+	interfaces  map[string]bool
+	members     map[string]bool
+	values      map[string]*valueDef
+}
+
+type fieldDef struct {
+	t                 ast.Type
+	description       string
+	deprecationReason *string
+	args              map[string]*inputDef
+}
+
+// inputDef is an argument or an input field.
+type inputDef struct {
+	t            ast.Type
+	description  string
+	defaultValue *string
+}
+
+type valueDef struct {
+	description       string
+	deprecationReason *string
+}
+
+const (
+	kindScalar    = "scalar"
+	kindObject    = "type"
+	kindInterface = "interface"
+	kindUnion     = "union"
+	kindEnum      = "enum"
+	kindInput     = "input"
+)
+
+// parseSchema parses the SDL informed.
+func parseSchema(sdl string) (*schema, error) {
+	doc, err := parser.Parse(parser.ParseParams{
+		Source: sdl,
+		Options: parser.ParseOptions{
+			NoLocation: true,
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s := &schema{
+		types: make(map[string]*typeDef),
+		roots: make(map[string]string),
+	}
+	for _, def := range doc.Definitions {
+		switch def := def.(type) {
+		case *ast.SchemaDefinition:
+			for _, op := range def.OperationTypes {
+				s.roots[op.Operation] = op.Type.Name.Value
+			}
+		case *ast.ScalarDefinition:
+			s.types[def.Name.Value] = &typeDef{
+				kind:        kindScalar,
+				description: stringValue(def.Description),
+			}
+		case *ast.ObjectDefinition:
+			t := &typeDef{
+				kind:        kindObject,
+				description: stringValue(def.Description),
+				fields:      parseFields(def.Fields),
+				interfaces:  make(map[string]bool),
+			}
+			for _, iface := range def.Interfaces {
+				t.interfaces[iface.Name.Value] = true
+			}
+			s.types[def.Name.Value] = t
+		case *ast.InterfaceDefinition:
+			s.types[def.Name.Value] = &typeDef{
+				kind:        kindInterface,
+				description: stringValue(def.Description),
+				fields:      parseFields(def.Fields),
+			}
+		case *ast.UnionDefinition:
+			t := &typeDef{
+				kind:        kindUnion,
+				description: stringValue(def.Description),
+				members:     make(map[string]bool),
+			}
+			for _, member := range def.Types {
+				t.members[member.Name.Value] = true
+			}
+			s.types[def.Name.Value] = t
+		case *ast.EnumDefinition:
+			t := &typeDef{
+				kind:        kindEnum,
+				description: stringValue(def.Description),
+				values:      make(map[string]*valueDef),
+			}
+			for _, value := range def.Values {
+				t.values[value.Name.Value] = &valueDef{
+					description:       stringValue(value.Description),
+					deprecationReason: deprecationReason(value.Directives),
+				}
+			}
+			s.types[def.Name.Value] = t
+		case *ast.InputObjectDefinition:
+			s.types[def.Name.Value] = &typeDef{
+				kind:        kindInput,
+				description: stringValue(def.Description),
+				inputFields: parseInputs(def.Fields),
+			}
+		}
+	}
+
+	if len(s.roots) == 0 {
+		// Without a schema definition, the conventional names are used.
+		for op, name := range map[string]string{
+			ast.OperationTypeQuery:        "Query",
+			ast.OperationTypeMutation:     "Mutation",
+			ast.OperationTypeSubscription: "Subscription",
+		} {
+			if _, ok := s.types[name]; ok {
+				s.roots[op] = name
+			}
+		}
+	}
+	return s, nil
+}
+
+func parseFields(defs []*ast.FieldDefinition) map[string]*fieldDef {
+	fields := make(map[string]*fieldDef, len(defs))
+	for _, def := range defs {
+		fields[def.Name.Value] = &fieldDef{
+			t:                 def.Type,
+			description:       stringValue(def.Description),
+			deprecationReason: deprecationReason(def.Directives),
+			args:              parseInputs(def.Arguments),
+		}
+	}
+	return fields
+}
+
+func parseInputs(defs []*ast.InputValueDefinition) map[string]*inputDef {
+	inputs := make(map[string]*inputDef, len(defs))
+	for _, def := range defs {
+		input := &inputDef{
+			t:           def.Type,
+			description: stringValue(def.Description),
+		}
+		if def.DefaultValue != nil {
+			value := printNode(def.DefaultValue)
+			input.defaultValue = &value
+		}
+		inputs[def.Name.Value] = input
+	}
+	return inputs
+}
+
+func stringValue(value *ast.StringValue) string {
+	if value == nil {
+		return ""
+	}
+	return value.Value
+}
+
+// deprecationReason returns the reason of the `@deprecated` directive, if
+// any, or nil when the element is not deprecated.
+func deprecationReason(directives []*ast.Directive) *string {
+	for _, directive := range directives {
+		if directive.Name.Value != "deprecated" {
+			continue
+		}
+		reason := graphql.DefaultDeprecationReason
+		for _, arg := range directive.Arguments {
+			if s, ok := arg.Value.(*ast.StringValue); ok && arg.Name.Value == "reason" {
+				reason = s.Value
+			}
+		}
+		return &reason
+	}
+	return nil
+}
+
+func printNode(node ast.Node) string {
+	s, _ := printer.Print(node).(string)
+	return s
+}
+
+// comparison accumulates the changes found while comparing two schemas.
+type comparison struct {
+	changes []Change
+}
+
+func (c *comparison) add(criticality Criticality, path, format string, args ...interface{}) {
+	c.changes = append(c.changes, Change{
+		Criticality: criticality,
+		Path:        path,
+		Message:     fmt.Sprintf(format, args...),
+	})
+}
+
+// compareSchemas returns the changes between the two schemas sorted by path.
+func compareSchemas(oldSchema, newSchema *schema) []Change {
+	c := &comparison{}
+
+	for _, op := range []string{ast.OperationTypeQuery, ast.OperationTypeMutation, ast.OperationTypeSubscription} {
+		oldRoot, newRoot := oldSchema.roots[op], newSchema.roots[op]
+		switch {
+		case oldRoot == newRoot:
+		case newRoot == "":
+			c.add(Breaking, oldRoot, "The %s root type '%s' was removed from the schema", op, oldRoot)
+		case oldRoot == "":
+			c.add(Safe, newRoot, "The %s root type '%s' was added to the schema", op, newRoot)
+		default:
+			c.add(Breaking, newRoot, "The %s root type changed from '%s' to '%s'", op, oldRoot, newRoot)
+		}
+	}
+
+	for _, name := range sortedKeys(oldSchema.types) {
+		oldType := oldSchema.types[name]
+		newType, ok := newSchema.types[name]
+		if !ok {
+			c.add(Breaking, name, "The %s '%s' was removed", oldType.kind, name)
+			continue
+		}
+		c.compareTypes(name, oldType, newType)
+	}
+	for _, name := range sortedKeys(newSchema.types) {
+		if _, ok := oldSchema.types[name]; !ok {
+			c.add(Safe, name, "The %s '%s' was added", newSchema.types[name].kind, name)
+		}
+	}
+
+	sort.SliceStable(c.changes, func(i, j int) bool {
+		return c.changes[i].Path < c.changes[j].Path
+	})
+	return c.changes
+}
+
+func (c *comparison) compareTypes(name string, oldType, newType *typeDef) {
+	if oldType.kind != newType.kind {
+		c.add(Breaking, name, "'%s' changed from %s to %s", name, oldType.kind, newType.kind)
+		return
+	}
+
+	if oldType.description != newType.description {
+		c.add(Safe, name, "The description of '%s' changed", name)
+	}
+
+	switch oldType.kind {
+	case kindObject, kindInterface:
+		c.compareFields(name, oldType.fields, newType.fields)
+		c.compareSets(name, oldType.interfaces, newType.interfaces, "'%s' no longer implements '%s'", "'%s' now implements '%s'")
+	case kindUnion:
+		c.compareSets(name, oldType.members, newType.members, "'%[2]s' was removed from the union '%[1]s'", "'%[2]s' was added to the union '%[1]s'")
+	case kindEnum:
+		c.compareValues(name, oldType.values, newType.values)
+	case kindInput:
+		c.compareInputs(name, oldType.inputFields, newType.inputFields, "input field")
+	}
+}
+
+// compareSets compares the interfaces of an object or the members of an
+// union. Removing them is breaking, adding them is dangerous.
+func (c *comparison) compareSets(name string, oldSet, newSet map[string]bool, removed, added string) {
+	for _, item := range sortedKeys(oldSet) {
+		if !newSet[item] {
+			c.add(Breaking, name, removed, name, item)
+		}
+	}
+	for _, item := range sortedKeys(newSet) {
+		if !oldSet[item] {
+			c.add(Dangerous, name, added, name, item)
+		}
+	}
+}
+
+func (c *comparison) compareFields(typeName string, oldFields, newFields map[string]*fieldDef) {
+	for _, name := range sortedKeys(oldFields) {
+		path := typeName + "." + name
+		oldField := oldFields[name]
+		newField, ok := newFields[name]
+		if !ok {
+			c.add(Breaking, path, "The field '%s' was removed", path)
+			continue
+		}
+
+		oldT, newT := printNode(oldField.t), printNode(newField.t)
+		if oldT != newT {
+			if isSafeOutputChange(oldField.t, newField.t) {
+				c.add(Safe, path, "The field '%s' changed type from '%s' to '%s'", path, oldT, newT)
+			} else {
+				c.add(Breaking, path, "The field '%s' changed type from '%s' to '%s'", path, oldT, newT)
+			}
+		}
+
+		if oldField.description != newField.description {
+			c.add(Safe, path, "The description of the field '%s' changed", path)
+		}
+		c.compareDeprecation(path, "field", oldField.deprecationReason, newField.deprecationReason)
+		c.compareInputs(path, oldField.args, newField.args, "argument")
+	}
+	for _, name := range sortedKeys(newFields) {
+		if _, ok := oldFields[name]; !ok {
+			path := typeName + "." + name
+			c.add(Safe, path, "The field '%s' was added", path)
+		}
+	}
+}
+
+// compareInputs compares the arguments of a field or the fields of an input
+// object.
+func (c *comparison) compareInputs(parent string, oldInputs, newInputs map[string]*inputDef, what string) {
+	for _, name := range sortedKeys(oldInputs) {
+		path := parent + "." + name
+		oldInput := oldInputs[name]
+		newInput, ok := newInputs[name]
+		if !ok {
+			c.add(Breaking, path, "The %s '%s' was removed", what, path)
+			continue
+		}
+
+		oldT, newT := printNode(oldInput.t), printNode(newInput.t)
+		if oldT != newT {
+			if isSafeInputChange(oldInput.t, newInput.t) {
+				c.add(Safe, path, "The %s '%s' changed type from '%s' to '%s'", what, path, oldT, newT)
+			} else {
+				c.add(Breaking, path, "The %s '%s' changed type from '%s' to '%s'", what, path, oldT, newT)
+			}
+		}
+
+		switch {
+		case oldInput.defaultValue == nil && newInput.defaultValue == nil:
+		case oldInput.defaultValue == nil:
+			c.add(Safe, path, "The %s '%s' now has the default value %s", what, path, *newInput.defaultValue)
+		case newInput.defaultValue == nil:
+			c.add(Dangerous, path, "The default value of the %s '%s' was removed", what, path)
+		case *oldInput.defaultValue != *newInput.defaultValue:
+			c.add(Dangerous, path, "The default value of the %s '%s' changed from %s to %s", what, path, *oldInput.defaultValue, *newInput.defaultValue)
+		}
+
+		if oldInput.description != newInput.description {
+			c.add(Safe, path, "The description of the %s '%s' changed", what, path)
+		}
+	}
+	for _, name := range sortedKeys(newInputs) {
+		if _, ok := oldInputs[name]; ok {
+			continue
+		}
+		path := parent + "." + name
+		newInput := newInputs[name]
+		if _, nonNull := newInput.t.(*ast.NonNull); nonNull && newInput.defaultValue == nil {
+			c.add(Breaking, path, "The required %s '%s' was added", what, path)
+		} else {
+			c.add(Dangerous, path, "The optional %s '%s' was added", what, path)
+		}
+	}
+}
+
+func (c *comparison) compareValues(typeName string, oldValues, newValues map[string]*valueDef) {
+	for _, name := range sortedKeys(oldValues) {
+		path := typeName + "." + name
+		oldValue := oldValues[name]
+		newValue, ok := newValues[name]
+		if !ok {
+			c.add(Breaking, path, "The enum value '%s' was removed", path)
+			continue
+		}
+		if oldValue.description != newValue.description {
+			c.add(Safe, path, "The description of the enum value '%s' changed", path)
+		}
+		c.compareDeprecation(path, "enum value", oldValue.deprecationReason, newValue.deprecationReason)
+	}
+	for _, name := range sortedKeys(newValues) {
+		if _, ok := oldValues[name]; !ok {
+			path := typeName + "." + name
+			c.add(Dangerous, path, "The enum value '%s' was added", path)
+		}
+	}
+}
+
+func (c *comparison) compareDeprecation(path, what string, oldReason, newReason *string) {
+	switch {
+	case oldReason == nil && newReason == nil:
+	case oldReason == nil:
+		c.add(Safe, path, "The %s '%s' was deprecated", what, path)
+	case newReason == nil:
+		c.add(Safe, path, "The %s '%s' is no longer deprecated", what, path)
+	case *oldReason != *newReason:
+		c.add(Safe, path, "The deprecation reason of the %s '%s' changed", what, path)
+	}
+}
+
+// isSafeOutputChange tells if the type of an output field can be changed
+// without breaking the clients. Output types can only become stricter
+// (nullable to non null).
+func isSafeOutputChange(oldT, newT ast.Type) bool {
+	switch oldT := oldT.(type) {
+	case *ast.List:
+		if newList, ok := newT.(*ast.List); ok {
+			return isSafeOutputChange(oldT.Type, newList.Type)
+		}
+		if newNonNull, ok := newT.(*ast.NonNull); ok {
+			return isSafeOutputChange(oldT, newNonNull.Type)
+		}
+		return false
+	case *ast.NonNull:
+		if newNonNull, ok := newT.(*ast.NonNull); ok {
+			return isSafeOutputChange(oldT.Type, newNonNull.Type)
+		}
+		return false
+	case *ast.Named:
+		switch newT := newT.(type) {
+		case *ast.NonNull:
+			return isSafeOutputChange(oldT, newT.Type)
+		case *ast.Named:
+			return oldT.Name.Value == newT.Name.Value
+		}
+	}
+	return false
+}
+
+// isSafeInputChange tells if the type of an argument or input field can be
+// changed without breaking the clients. Input types can only become looser
+// (non null to nullable).
+func isSafeInputChange(oldT, newT ast.Type) bool {
+	switch oldT := oldT.(type) {
+	case *ast.List:
+		if newList, ok := newT.(*ast.List); ok {
+			return isSafeInputChange(oldT.Type, newList.Type)
+		}
+		return false
+	case *ast.NonNull:
+		if newNonNull, ok := newT.(*ast.NonNull); ok {
+			return isSafeInputChange(oldT.Type, newNonNull.Type)
+		}
+		return isSafeInputChange(oldT.Type, newT)
+	case *ast.Named:
+		if newNamed, ok := newT.(*ast.Named); ok {
+			return oldT.Name.Value == newNamed.Name.Value
+		}
+	}
+	return false
+}
+
+// sortedKeys returns the keys of a map indexed by string, sorted.
+func sortedKeys(m interface{}) []string {
+	var keys []string
+	switch m := m.(type) {
+	case map[string]*typeDef:
+		for k := range m {
+			keys = append(keys, k)
+		}
+	case map[string]*fieldDef:
+		for k := range m {
+			keys = append(keys, k)
+		}
+	case map[string]*inputDef:
+		for k := range m {
+			keys = append(keys, k)
+		}
+	case map[string]*valueDef:
+		for k := range m {
+			keys = append(keys, k)
+		}
+	case map[string]bool:
+		for k := range m {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
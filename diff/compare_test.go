@@ -0,0 +1,403 @@
+package diff_test
+
+import (
+	"github.com/graphql-go/graphql"
+	"github.com/lab259/go-graphql-struct"
+	"github.com/lab259/go-graphql-struct/diff"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+const baseSDL = `
+type Query {
+  user(id: ID!, active: Boolean = true): User
+  users(first: Int): [User!]!
+  search(term: String!): SearchResult
+}
+
+interface Node {
+  id: ID!
+}
+
+type User implements Node {
+  id: ID!
+  name: String
+  email: String!
+  status: Status
+}
+
+type Group implements Node {
+  id: ID!
+}
+
+union SearchResult = User | Group
+
+enum Status {
+  ACTIVE
+  BLOCKED
+}
+
+input UserFilter {
+  name: String
+  status: Status!
+}
+`
+
+// compare returns the changes between baseSDL and the SDL informed.
+func compare(sdl string) []diff.Change {
+	changes, err := diff.SDL(baseSDL, sdl)
+	Expect(err).ToNot(HaveOccurred())
+	return changes
+}
+
+// change finds the change of the path informed.
+func change(changes []diff.Change, path string) diff.Change {
+	for _, c := range changes {
+		if c.Path == path {
+			return c
+		}
+	}
+	Fail("change not found: " + path)
+	return diff.Change{}
+}
+
+var _ = Describe("Diff", func() {
+	It("should not find changes in the same schema", func() {
+		Expect(compare(baseSDL)).To(BeEmpty())
+	})
+
+	It("should ignore the order of the definitions", func() {
+		Expect(compare(`
+enum Status {
+  BLOCKED
+  ACTIVE
+}
+
+input UserFilter {
+  status: Status!
+  name: String
+}
+
+union SearchResult = Group | User
+
+type Group implements Node {
+  id: ID!
+}
+
+type User implements Node {
+  status: Status
+  email: String!
+  name: String
+  id: ID!
+}
+
+interface Node {
+  id: ID!
+}
+
+type Query {
+  search(term: String!): SearchResult
+  users(first: Int): [User!]!
+  user(active: Boolean = true, id: ID!): User
+}
+`)).To(BeEmpty())
+	})
+
+	It("should classify the changes of types", func() {
+		changes := compare(`
+type Query {
+  user(id: ID!, active: Boolean = true): User
+  users(first: Int): [User!]!
+  search(term: String!): SearchResult
+}
+
+interface Node {
+  id: ID!
+}
+
+type User implements Node {
+  id: ID!
+  name: String
+  email: String!
+  status: Status
+}
+
+input Group {
+  id: ID!
+}
+
+union SearchResult = User
+
+enum Status {
+  ACTIVE
+  BLOCKED
+}
+
+input UserFilter {
+  name: String
+  status: Status!
+}
+
+scalar DateTime
+`)
+		Expect(change(changes, "Group").Criticality).To(Equal(diff.Breaking))
+		Expect(change(changes, "Group").Message).To(Equal("'Group' changed from type to input"))
+		Expect(change(changes, "SearchResult").Criticality).To(Equal(diff.Breaking))
+		Expect(change(changes, "DateTime").Criticality).To(Equal(diff.Safe))
+		Expect(change(changes, "DateTime").Message).To(Equal("The scalar 'DateTime' was added"))
+	})
+
+	It("should classify the changes of fields", func() {
+		changes := compare(`
+type Query {
+  user(id: ID!, active: Boolean = true): User
+  users(first: Int): [User!]
+  search(term: String!): SearchResult
+}
+
+interface Node {
+  id: ID!
+}
+
+type User implements Node {
+  id: ID!
+  name: String!
+  status: Status @deprecated(reason: "Use state.")
+  createdAt: String
+}
+
+type Group implements Node {
+  id: ID!
+}
+
+union SearchResult = User | Group
+
+enum Status {
+  ACTIVE
+  BLOCKED
+}
+
+input UserFilter {
+  name: String
+  status: Status!
+}
+`)
+		Expect(changes).To(HaveLen(5))
+		Expect(change(changes, "Query.users")).To(Equal(diff.Change{
+			Criticality: diff.Breaking,
+			Path:        "Query.users",
+			Message:     "The field 'Query.users' changed type from '[User!]!' to '[User!]'",
+		}))
+		Expect(change(changes, "User.email").Criticality).To(Equal(diff.Breaking))
+		Expect(change(changes, "User.name").Criticality).To(Equal(diff.Safe))
+		Expect(change(changes, "User.status").Message).To(Equal("The field 'User.status' was deprecated"))
+		Expect(change(changes, "User.createdAt").Criticality).To(Equal(diff.Safe))
+	})
+
+	It("should classify the changes of arguments", func() {
+		changes := compare(`
+type Query {
+  user(id: ID, active: Boolean = false): User
+  users(first: Int!, after: String): [User!]!
+  search(term: String!, limit: Int!): SearchResult
+}
+
+interface Node {
+  id: ID!
+}
+
+type User implements Node {
+  id: ID!
+  name: String
+  email: String!
+  status: Status
+}
+
+type Group implements Node {
+  id: ID!
+}
+
+union SearchResult = User | Group
+
+enum Status {
+  ACTIVE
+  BLOCKED
+}
+
+input UserFilter {
+  name: String
+  status: Status!
+}
+`)
+		Expect(changes).To(HaveLen(5))
+		Expect(change(changes, "Query.user.id").Criticality).To(Equal(diff.Safe))
+		Expect(change(changes, "Query.user.active")).To(Equal(diff.Change{
+			Criticality: diff.Dangerous,
+			Path:        "Query.user.active",
+			Message:     "The default value of the argument 'Query.user.active' changed from true to false",
+		}))
+		Expect(change(changes, "Query.users.first").Criticality).To(Equal(diff.Breaking))
+		Expect(change(changes, "Query.users.after").Criticality).To(Equal(diff.Dangerous))
+		Expect(change(changes, "Query.search.limit")).To(Equal(diff.Change{
+			Criticality: diff.Breaking,
+			Path:        "Query.search.limit",
+			Message:     "The required argument 'Query.search.limit' was added",
+		}))
+	})
+
+	It("should classify the changes of enums, unions, interfaces and inputs", func() {
+		changes := compare(`
+type Query {
+  user(id: ID!, active: Boolean = true): User
+  users(first: Int): [User!]!
+  search(term: String!): SearchResult
+}
+
+interface Node {
+  id: ID!
+}
+
+type User {
+  id: ID!
+  name: String
+  email: String!
+  status: Status
+}
+
+type Group implements Node {
+  id: ID!
+}
+
+type Team {
+  id: ID!
+}
+
+union SearchResult = User | Group | Team
+
+enum Status {
+  ACTIVE
+  REMOVED
+}
+
+input UserFilter {
+  name: String!
+  status: Status
+  email: String
+}
+`)
+		Expect(change(changes, "Status.BLOCKED").Criticality).To(Equal(diff.Breaking))
+		Expect(change(changes, "Status.REMOVED").Criticality).To(Equal(diff.Dangerous))
+		Expect(change(changes, "SearchResult")).To(Equal(diff.Change{
+			Criticality: diff.Dangerous,
+			Path:        "SearchResult",
+			Message:     "'Team' was added to the union 'SearchResult'",
+		}))
+		Expect(change(changes, "User")).To(Equal(diff.Change{
+			Criticality: diff.Breaking,
+			Path:        "User",
+			Message:     "'User' no longer implements 'Node'",
+		}))
+		Expect(change(changes, "UserFilter.name").Criticality).To(Equal(diff.Breaking))
+		Expect(change(changes, "UserFilter.status").Criticality).To(Equal(diff.Safe))
+		Expect(change(changes, "UserFilter.email").Criticality).To(Equal(diff.Dangerous))
+	})
+
+	It("should detect the changes of the root types", func() {
+		changes := compare(baseSDL + `
+schema {
+  query: Query
+  mutation: User
+}
+`)
+		Expect(changes).To(Equal([]diff.Change{
+			{
+				Criticality: diff.Safe,
+				Path:        "User",
+				Message:     "The mutation root type 'User' was added to the schema",
+			},
+		}))
+	})
+
+	It("should use the default reason of the deprecations without one", func() {
+		changes, err := diff.SDL(
+			`type Query { name: String @deprecated }`,
+			`type Query { name: String @deprecated(reason: "No longer supported") }`,
+		)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(changes).To(BeEmpty())
+	})
+
+	It("should filter the changes by criticality", func() {
+		changes := []diff.Change{
+			{Criticality: diff.Safe, Path: "A"},
+			{Criticality: diff.Breaking, Path: "B"},
+			{Criticality: diff.Dangerous, Path: "C"},
+		}
+		Expect(diff.Filter(changes, diff.Breaking)).To(Equal(changes[1:2]))
+		Expect(diff.Filter(changes, diff.Dangerous)).To(Equal(changes[1:]))
+		Expect(diff.Filter(changes, diff.Safe)).To(Equal(changes))
+	})
+
+	It("should fail with invalid SDL", func() {
+		_, err := diff.SDL(baseSDL, "type Query {")
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(HavePrefix("new schema: "))
+	})
+
+	It("should compare the types built by two encoders", func() {
+		oldEnc, newEnc := gqlstruct.NewEncoder(), gqlstruct.NewEncoder()
+		func() {
+			type DiffUser struct {
+				Name  string `graphql:"name"`
+				Email string `graphql:"email"`
+			}
+			_, err := oldEnc.Struct(&DiffUser{})
+			Expect(err).ToNot(HaveOccurred())
+		}()
+		func() {
+			type DiffUser struct {
+				Name string `graphql:"!name"`
+			}
+			_, err := newEnc.Struct(&DiffUser{})
+			Expect(err).ToNot(HaveOccurred())
+		}()
+
+		changes, err := diff.Encoders(oldEnc, newEnc)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(changes).To(Equal([]diff.Change{
+			{
+				Criticality: diff.Breaking,
+				Path:        "DiffUser.email",
+				Message:     "The field 'DiffUser.email' was removed",
+			},
+			{
+				Criticality: diff.Safe,
+				Path:        "DiffUser.name",
+				Message:     "The field 'DiffUser.name' changed type from 'String' to 'String!'",
+			},
+		}))
+	})
+
+	It("should compare two schemas", func() {
+		newSchema := func(fields graphql.Fields) graphql.Schema {
+			schema, err := graphql.NewSchema(graphql.SchemaConfig{
+				Query: graphql.NewObject(graphql.ObjectConfig{
+					Name:   "Query",
+					Fields: fields,
+				}),
+			})
+			Expect(err).ToNot(HaveOccurred())
+			return schema
+		}
+
+		changes, err := diff.Schemas(newSchema(graphql.Fields{
+			"hello": &graphql.Field{Type: graphql.String},
+		}), newSchema(graphql.Fields{
+			"hello": &graphql.Field{Type: graphql.Int},
+		}))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(changes).To(HaveLen(1))
+		Expect(changes[0].Criticality).To(Equal(diff.Breaking))
+		Expect(changes[0].String()).To(Equal("breaking: The field 'Query.hello' changed type from 'String' to 'Int'"))
+	})
+})
@@ -0,0 +1,108 @@
+// Package diff detects the changes between two GraphQL schemas and
+// classifies them as breaking, dangerous or safe.
+//
+// The schemas are compared through their SDL, so an encoder can be compared
+// to another encoder, to a `graphql.Schema` or to a committed `.graphql`
+// file:
+//
+// ```
+// changes, err := diff.File("schema.graphql", enc)
+// ```
+package diff
+
+import (
+	"fmt"
+	"github.com/graphql-go/graphql"
+	"github.com/lab259/go-graphql-struct"
+	"io/ioutil"
+)
+
+// Criticality tells how a change affects the clients of a schema.
+type Criticality int
+
+const (
+	// Safe changes do not affect the existing clients.
+	Safe Criticality = iota
+	// Dangerous changes do not break the existing queries, but may change
+	// their behavior (eg. a new enum value that a client does not handle).
+	Dangerous
+	// Breaking changes make existing queries fail.
+	Breaking
+)
+
+func (c Criticality) String() string {
+	switch c {
+	case Safe:
+		return "safe"
+	case Dangerous:
+		return "dangerous"
+	case Breaking:
+		return "breaking"
+	}
+	return fmt.Sprintf("Criticality(%d)", int(c))
+}
+
+// Change is a difference between two schemas.
+type Change struct {
+	Criticality Criticality
+	// Path is the schema coordinate of the element changed: `Type`,
+	// `Type.field`, `Type.field.argument` or `Enum.VALUE`.
+	Path    string
+	Message string
+}
+
+func (c Change) String() string {
+	return fmt.Sprintf("%s: %s", c.Criticality, c.Message)
+}
+
+// Printer is implemented by the types that can print their GraphQL SDL, as
+// the gqlstruct encoder does.
+type Printer interface {
+	PrintSDL() string
+}
+
+// SDL compares two schemas described by their SDL.
+//
+// The changes are sorted by path.
+func SDL(oldSDL, newSDL string) ([]Change, error) {
+	oldSchema, err := parseSchema(oldSDL)
+	if err != nil {
+		return nil, fmt.Errorf("old schema: %s", err)
+	}
+	newSchema, err := parseSchema(newSDL)
+	if err != nil {
+		return nil, fmt.Errorf("new schema: %s", err)
+	}
+	return compareSchemas(oldSchema, newSchema), nil
+}
+
+// Encoders compares the types built by two encoders.
+func Encoders(oldEnc, newEnc Printer) ([]Change, error) {
+	return SDL(oldEnc.PrintSDL(), newEnc.PrintSDL())
+}
+
+// Schemas compares two `graphql.Schema`s.
+func Schemas(oldSchema, newSchema graphql.Schema) ([]Change, error) {
+	return SDL(gqlstruct.PrintSchema(oldSchema), gqlstruct.PrintSchema(newSchema))
+}
+
+// File compares the schema committed in the file informed to the types
+// built by the encoder.
+func File(filename string, enc Printer) ([]Change, error) {
+	data, err := ioutil.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+	return SDL(string(data), enc.PrintSDL())
+}
+
+// Filter returns the changes with, at least, the criticality informed.
+func Filter(changes []Change, criticality Criticality) []Change {
+	r := make([]Change, 0, len(changes))
+	for _, change := range changes {
+		if change.Criticality >= criticality {
+			r = append(r, change)
+		}
+	}
+	return r
+}
@@ -0,0 +1,15 @@
+package diff_test
+
+import (
+	"github.com/jamillosantos/macchiato"
+	"github.com/onsi/ginkgo"
+	"github.com/onsi/gomega"
+	"log"
+	"testing"
+)
+
+func TestDiff(t *testing.T) {
+	log.SetOutput(ginkgo.GinkgoWriter)
+	gomega.RegisterFailHandler(ginkgo.Fail)
+	macchiato.RunSpecs(t, "gqlstruct: Schema Diff Test Suite")
+}
@@ -0,0 +1,49 @@
+package diff
+
+// TestingT is the subset of `*testing.T` used by the assertions. Ginkgo's
+// `GinkgoT()` can be used as well.
+type TestingT interface {
+	Errorf(format string, args ...interface{})
+}
+
+// AssertNoBreakingChanges fails the test when the types built by the
+// encoder introduce breaking changes to the schema committed in the file
+// informed.
+//
+// Breaking changes that were agreed on can be approved by their path:
+//
+// ```
+// func TestSchema(t *testing.T) {
+//     enc := gqlstruct.NewEncoder()
+//     enc.Schema(&Query{}, &Mutation{}, nil)
+//     diff.AssertNoBreakingChanges(t, "schema.graphql", enc, "User.login")
+// }
+// ```
+//
+// It returns true when no unapproved breaking changes were found.
+func AssertNoBreakingChanges(t TestingT, filename string, enc Printer, approved ...string) bool {
+	if h, ok := t.(interface{ Helper() }); ok {
+		h.Helper()
+	}
+
+	changes, err := File(filename, enc)
+	if err != nil {
+		t.Errorf("could not compare the schema to %s: %s", filename, err)
+		return false
+	}
+
+	approvedPaths := make(map[string]bool, len(approved))
+	for _, path := range approved {
+		approvedPaths[path] = true
+	}
+
+	ok := true
+	for _, change := range Filter(changes, Breaking) {
+		if approvedPaths[change.Path] {
+			continue
+		}
+		t.Errorf("%s: unapproved breaking change: %s", filename, change.Message)
+		ok = false
+	}
+	return ok
+}
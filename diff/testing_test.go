@@ -0,0 +1,81 @@
+package diff_test
+
+import (
+	"fmt"
+	"github.com/lab259/go-graphql-struct"
+	"github.com/lab259/go-graphql-struct/diff"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+)
+
+// fakeT records the errors reported by the assertions.
+type fakeT struct {
+	errors []string
+}
+
+func (t *fakeT) Errorf(format string, args ...interface{}) {
+	t.errors = append(t.errors, fmt.Sprintf(format, args...))
+}
+
+type AssertUser struct {
+	Name string `graphql:"name"`
+}
+
+var _ = Describe("AssertNoBreakingChanges", func() {
+	var (
+		dir      string
+		filename string
+		enc      interface{ PrintSDL() string }
+	)
+
+	BeforeEach(func() {
+		var err error
+		dir, err = ioutil.TempDir("", "gqlstruct-diff")
+		Expect(err).ToNot(HaveOccurred())
+		filename = filepath.Join(dir, "schema.graphql")
+
+		e := gqlstruct.NewEncoder()
+		_, err = e.Struct(&AssertUser{})
+		Expect(err).ToNot(HaveOccurred())
+		enc = e
+	})
+
+	AfterEach(func() {
+		Expect(os.RemoveAll(dir)).To(Succeed())
+	})
+
+	It("should pass when the schema is compatible", func() {
+		Expect(ioutil.WriteFile(filename, []byte(`type AssertUser { name: String }`), 0644)).To(Succeed())
+
+		t := &fakeT{}
+		Expect(diff.AssertNoBreakingChanges(t, filename, enc)).To(BeTrue())
+		Expect(t.errors).To(BeEmpty())
+	})
+
+	It("should fail with unapproved breaking changes", func() {
+		Expect(ioutil.WriteFile(filename, []byte(`type AssertUser { name: String, email: String }`), 0644)).To(Succeed())
+
+		t := &fakeT{}
+		Expect(diff.AssertNoBreakingChanges(t, filename, enc)).To(BeFalse())
+		Expect(t.errors).To(Equal([]string{
+			filename + ": unapproved breaking change: The field 'AssertUser.email' was removed",
+		}))
+	})
+
+	It("should pass when the breaking changes were approved", func() {
+		Expect(ioutil.WriteFile(filename, []byte(`type AssertUser { name: String, email: String }`), 0644)).To(Succeed())
+
+		t := &fakeT{}
+		Expect(diff.AssertNoBreakingChanges(t, filename, enc, "AssertUser.email")).To(BeTrue())
+		Expect(t.errors).To(BeEmpty())
+	})
+
+	It("should fail when the file cannot be read", func() {
+		t := &fakeT{}
+		Expect(diff.AssertNoBreakingChanges(t, filename, enc)).To(BeFalse())
+		Expect(t.errors).To(HaveLen(1))
+	})
+})
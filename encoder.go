@@ -1,24 +1,95 @@
 package gqlstruct
 
 import (
+	"bytes"
+	"errors"
 	"fmt"
 	"github.com/graphql-go/graphql"
 	"reflect"
+	"runtime"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
 )
 
 type encoder struct {
-	types map[string]graphql.Type
+	config encoderConfig
+	// mu guards the whole build of the types, not only the maps below. So,
+	// the types being built (as recursive structs) are only seen by the
+	// goroutine building them. It is taken by `lock`, as the hooks called
+	// while building may use the encoder again.
+	mu sync.Mutex
+	// owner is the id of the goroutine holding mu (0 when it is not held).
+	owner int64
+	// types and inputTypes are the cache of the types already built, indexed
+	// by the Go type they were built from.
+	types      map[reflect.Type]graphql.Type
+	inputTypes map[reflect.Type]graphql.Input
+	// names keeps track of the GraphQL names already taken by the types built
+	// by this encoder.
+	names map[string]namedType
+	// connections are the connections built, indexed by the type of their
+	// nodes, and pageInfoType is the `PageInfo` type shared by them.
+	connections  map[graphql.Type]*graphql.Object
+	pageInfoType *graphql.Object
+	// filters are the filter types built, indexed by their model, and
+	// conditions are the conditions of the scalars and enums used by them.
+	filters    map[reflect.Type]*filterTypes
+	conditions map[graphql.Input]*graphql.InputObject
+	// middlewares wrap the resolvers of the fields of the objects built
+	// (check `Use`).
+	middlewares []Middleware
+	// policies are the policies registered (check `RegisterPolicy`), and
+	// fieldPolicies are the names of the policies of the fields of the
+	// objects built, indexed by object and field, so they are printed.
+	policies      map[string]Policy
+	fieldPolicies map[*graphql.Object]map[string][]string
+
+	buildState
 }
 
-func NewEncoder() *encoder {
+// buildState is the state of the current call of an encoder.
+type buildState struct {
+	// path is the path of the field being built, from the first type built
+	// by the current call. It is used, along with errs, when the errors are
+	// aggregated.
+	path []string
+	errs SchemaErrors
+	// connectionLists are the resolvers of the lists paginated by the
+	// connections built by the current call (nil for the connections resolved
+	// by `WithConnectionResolve`), so filters can be applied to them.
+	connectionLists map[*graphql.Field]graphql.FieldResolveFn
+	// rollback undoes the changes of the caches made by the current call, so
+	// the types of a call that fails are not kept.
+	rollback []func()
+}
+
+// namedType is a GraphQL named type along with the Go type it was built from.
+type namedType struct {
+	t  reflect.Type
+	gt graphql.Type
+}
+
+// NewEncoder creates an encoder with its own cache of types. Encoders are
+// safe for concurrent use.
+func NewEncoder(options ...EncoderOption) *encoder {
 	return &encoder{
-		types: make(map[string]graphql.Type),
+		config:      newEncoderConfig(options...),
+		types:       make(map[reflect.Type]graphql.Type),
+		inputTypes:  make(map[reflect.Type]graphql.Input),
+		names:       make(map[string]namedType),
+		connections: make(map[graphql.Type]*graphql.Object),
+		filters:     make(map[reflect.Type]*filterTypes),
+		conditions:  make(map[graphql.Input]*graphql.InputObject),
+
+		fieldPolicies: make(map[*graphql.Object]map[string][]string),
 	}
 }
 
 var defaultEncoder = NewEncoder()
 
-func (enc *encoder) Struct(obj interface{}, options ...Option) (*graphql.Object, error) {
+func (enc *encoder) Struct(obj interface{}, options ...StructOption) (*graphql.Object, error) {
 	t := reflect.TypeOf(obj)
 	return enc.StructOf(t, options...)
 }
@@ -37,13 +108,42 @@ func (enc *encoder) Args(obj interface{}) (graphql.FieldConfigArgument, error) {
 // The "graphql" tag can be defined as:
 //
 // ```
-// type T struct {
-//     field string `graphql:"fieldname"`
-// }
+//
+//	type T struct {
+//	    field string `graphql:"fieldname"`
+//	}
+//
 // ```
 //
 // * fieldname: The name of the field.
-func (enc *encoder) StructOf(t reflect.Type, options ...Option) (*graphql.Object, error) {
+//
+// The name can be followed by comma separated options (check `ParseTag`):
+//
+// ```
+//
+//	type T struct {
+//	    field string `graphql:"fieldname,desc=The description,deprecated=The reason"`
+//	}
+//
+// ```
+//
+// Embedded structs tagged with the "interface" option (`graphql:",interface"`)
+// are described as `*graphql.Interface`s implemented by the object. Their
+// fields are promoted to the object.
+//
+// Options of single fields are applied by `WithFieldOptions` or by the
+// `GraphqlFieldOptions` interface.
+func (enc *encoder) StructOf(t reflect.Type, options ...StructOption) (*graphql.Object, error) {
+	defer enc.lock()()
+	r, err := enc.structOf(t, options...)
+	if err := enc.endBuild(err); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// structOf implements `StructOf`, without locking the encoder.
+func (enc *encoder) structOf(t reflect.Type, options ...StructOption) (*graphql.Object, error) {
 	if r, ok := enc.getType(t); ok {
 		if d, ok := r.(*graphql.Object); ok {
 			return d, nil
@@ -55,87 +155,241 @@ func (enc *encoder) StructOf(t reflect.Type, options ...Option) (*graphql.Object
 	if t.Kind() == reflect.Ptr {
 		name = t.Elem().Name()
 	}
+	name = enc.config.typeName(name)
+	defer enc.rootPath(name)()
+
+	// The interfaces are only known after going through the fields.
+	var interfaces []*graphql.Interface
 
 	objCfg := graphql.ObjectConfig{
 		Name:   name,
 		Fields: graphql.Fields{},
+		Interfaces: graphql.InterfacesThunk(func() []*graphql.Interface {
+			return interfaces
+		}),
+	}
+
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	structCfg := StructConfig{
+		ObjectConfig: objCfg,
+		Methods:      structMethods(t),
+		FieldOptions: structFieldOptions(t),
 	}
 
 	// Apply options
 	for _, opt := range options {
-		err := opt.Apply(&objCfg)
+		err := opt.ApplyStruct(&structCfg)
 		if err != nil {
 			return nil, err
 		}
 	}
+	objCfg, methods, fieldOptions := structCfg.ObjectConfig, structCfg.Methods, structCfg.FieldOptions
 
 	r := graphql.NewObject(objCfg)
-	enc.registerType(t, r)
+	if err := enc.registerType(t, r); err != nil {
+		return nil, err
+	}
 
-	if t.Kind() == reflect.Ptr {
-		t = t.Elem()
+	fields, interfaces, err := enc.buildFields(t)
+	if err != nil {
+		return nil, err
+	}
+
+	methodFields, err := enc.buildMethodFields(t, methods)
+	if err != nil {
+		return nil, err
+	}
+	for name, field := range methodFields {
+		if _, ok := fields[name]; ok {
+			return nil, fmt.Errorf("%s: the field '%s' is already defined", t.Name(), name)
+		}
+		fields[name] = field
+	}
+
+	if err := enc.applyStructFieldOptions(t, fields, fieldOptions); err != nil {
+		return nil, err
+	}
+	infos := enc.fieldInfos(t, methods)
+	if err := enc.applyPolicies(r, t, fields, infos); err != nil {
+		return nil, err
+	}
+	enc.applyMiddlewares(fields, infos)
+
+	for name, field := range fields {
+		r.AddFieldConfig(name, field)
 	}
-	// Goes field by field of the object.
 
+	if len(interfaces) > 0 {
+		// Objects that implement interfaces must be identified when an
+		// abstract type is resolved.
+		r.IsTypeOf = isTypeOf(t)
+	}
+	return r, nil
+}
+
+// buildFields builds the fields of the struct informed.
+//
+// The fields of the embedded structs tagged as "interface" are promoted to
+// the struct and the `*graphql.Interface`s built from them are returned.
+func (enc *encoder) buildFields(t reflect.Type) (graphql.Fields, []*graphql.Interface, error) {
+	fields := graphql.Fields{}
+	interfaces := make([]*graphql.Interface, 0)
+
+	// Goes field by field of the object.
 	for i := 0; i < t.NumField(); i++ {
 		field := t.Field(i)
-		tag, ok := field.Tag.Lookup("graphql")
+		tag, ok := enc.config.fieldTag(field)
 		if !ok {
-			// If the field is not tagged, ignore it.
+			// If the field is not described, ignore it.
+			continue
+		}
+
+		info, err := ParseTag(tag)
+		if err != nil {
+			// The name may be invalid, so the path uses the name of the Go field.
+			if err := enc.fieldError(field.Name, field.Type, newErrInvalidTag(err, t, field, tag)); err != nil {
+				return nil, nil, err
+			}
+			continue
+		}
+
+		if info.HasDefault {
+			if err := enc.fieldError(info.Name, field.Type, newErrInvalidTag(errors.New("default values are only supported by arguments and input fields"), t, field, tag)); err != nil {
+				return nil, nil, err
+			}
+			continue
+		}
+
+		if info.Interface {
+			if !field.Anonymous {
+				if err := enc.fieldError(field.Name, field.Type, newErrInvalidTag(errors.New("only embedded structs can be interfaces"), t, field, tag)); err != nil {
+					return nil, nil, err
+				}
+				continue
+			}
+			iface, err := enc.interfaceOf(field.Type)
+			if err != nil {
+				if err := enc.fieldError(field.Name, field.Type, NewErrTypeNotRecognizedWithStruct(err, t, field)); err != nil {
+					return nil, nil, err
+				}
+				continue
+			}
+			embeddedType := field.Type
+			if embeddedType.Kind() == reflect.Ptr {
+				embeddedType = embeddedType.Elem()
+			}
+			embeddedFields, embeddedInterfaces, err := enc.buildFields(embeddedType)
+			if err != nil {
+				return nil, nil, err
+			}
+			for name, embeddedField := range embeddedFields {
+				if _, ok := fields[name]; ok {
+					// Fields declared by the struct itself take precedence.
+					continue
+				}
+				embeddedField.Resolve = embeddedFieldResolve(t, i, embeddedField.Resolve)
+				if listResolve := enc.connectionLists[embeddedField]; listResolve != nil {
+					// Filters wrap the list of the connection, instead of the
+					// connection.
+					enc.connectionLists[embeddedField] = embeddedFieldResolve(t, i, listResolve)
+				}
+				fields[name] = embeddedField
+			}
+			interfaces = append(interfaces, iface)
+			interfaces = append(interfaces, embeddedInterfaces...)
 			continue
 		}
 
 		objectType, ok := enc.getType(field.Type)
 		if !ok {
+			popPath := enc.pushPath(info.Name)
 			ot, err := enc.buildFieldType(field.Type)
+			popPath()
 			if err != nil {
-				return nil, NewErrTypeNotRecognizedWithStruct(err, t, field)
+				if err := enc.fieldError(info.Name, field.Type, NewErrTypeNotRecognizedWithStruct(err, t, field)); err != nil {
+					return nil, nil, err
+				}
+				continue
 			}
 			objectType = ot
-			enc.registerType(field.Type, ot)
+			if err := enc.registerType(field.Type, ot); err != nil {
+				if err := enc.fieldError(info.Name, field.Type, err); err != nil {
+					return nil, nil, err
+				}
+				continue
+			}
 		}
 
-		// If the tag starts with "!" it is a NonNull type.
-		if len(tag) > 0 && tag[0] == '!' {
-			objectType = graphql.NewNonNull(objectType)
-			tag = tag[1:]
+		r := &graphql.Field{
+			Type:              objectType,
+			Resolve:           fieldResolve(t, i),
+			Description:       info.Description,
+			DeprecationReason: info.DeprecationReason,
 		}
 
-		resolve := fieldResolve(field)
+		if info.Connection {
+			if err := enc.connectionField(r, nil); err != nil {
+				if err := enc.fieldError(info.Name, field.Type, fmt.Errorf("%s.%s: %s", t.Name(), field.Name, err.Error())); err != nil {
+					return nil, nil, err
+				}
+				continue
+			}
+		}
 
-		gfield := &graphql.Field{
-			Type:    objectType,
-			Resolve: resolve,
+		if info.NonNull {
+			r.Type = graphql.NewNonNull(r.Type)
 		}
-		r.AddFieldConfig(tag, gfield)
+
+		fields[info.Name] = r
+	}
+	return fields, interfaces, nil
+}
+
+func (enc *encoder) FieldOf(t reflect.Type, options ...FieldOption) (graphql.Field, error) {
+	defer enc.lock()()
+	r, err := enc.fieldOf(t, options...)
+	if err := enc.endBuild(err); err != nil {
+		return graphql.Field{}, err
 	}
 	return r, nil
 }
 
-func (enc *encoder) FieldOf(t reflect.Type, options ...Option) (graphql.Field, error) {
+// fieldOf implements `FieldOf`, without locking the encoder.
+func (enc *encoder) fieldOf(t reflect.Type, options ...FieldOption) (graphql.Field, error) {
 	r := graphql.Field{}
 
-	fieldType, err := enc.StructOf(t)
+	fieldType, err := enc.structOf(t)
 	if err != nil {
 		return graphql.Field{}, err
 	}
 	r.Type = fieldType
 
-	for _, option := range options {
-		err = option.Apply(&r)
-		if err != nil {
-			return graphql.Field{}, err
-		}
+	if err := enc.applyFieldOptions(&r, options); err != nil {
+		return graphql.Field{}, err
 	}
 
 	return r, nil
 }
 
-func (enc *encoder) Field(t interface{}, options ...Option) (graphql.Field, error) {
+func (enc *encoder) Field(t interface{}, options ...FieldOption) (graphql.Field, error) {
 	return enc.FieldOf(reflect.TypeOf(t), options...)
 }
 
-func (enc *encoder) ArrayOf(t reflect.Type, options ...Option) (graphql.Type, error) {
+func (enc *encoder) ArrayOf(t reflect.Type, options ...StructOption) (graphql.Type, error) {
+	defer enc.lock()()
+	r, err := enc.arrayOf(t, options...)
+	if err := enc.endBuild(err); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// arrayOf implements `ArrayOf`, without locking the encoder.
+func (enc *encoder) arrayOf(t reflect.Type, options ...StructOption) (graphql.Type, error) {
+	defer enc.listPath()()
 	if t.Kind() == reflect.Ptr {
 		// If pointer, get the Type of the pointer
 		t = t.Elem()
@@ -145,7 +399,7 @@ func (enc *encoder) ArrayOf(t reflect.Type, options ...Option) (graphql.Type, er
 		return graphql.NewList(cachedType), nil
 	}
 	if t.Kind() == reflect.Struct {
-		bt, err := enc.StructOf(t, options...)
+		bt, err := enc.structOf(t, options...)
 		if err != nil {
 			return nil, err
 		}
@@ -157,11 +411,23 @@ func (enc *encoder) ArrayOf(t reflect.Type, options ...Option) (graphql.Type, er
 		}
 		typeBuilt = ttt
 	}
-	enc.registerType(t, typeBuilt)
+	if err := enc.registerType(t, typeBuilt); err != nil {
+		return nil, err
+	}
 	return graphql.NewList(typeBuilt), nil
 }
 
 func (enc *encoder) ArgsOf(t reflect.Type) (graphql.FieldConfigArgument, error) {
+	defer enc.lock()()
+	r, err := enc.argsOf(t)
+	if err := enc.endBuild(err); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// argsOf implements `ArgsOf`, without locking the encoder.
+func (enc *encoder) argsOf(t reflect.Type) (graphql.FieldConfigArgument, error) {
 	r := graphql.FieldConfigArgument{}
 
 	if t.Kind() == reflect.Ptr {
@@ -171,60 +437,212 @@ func (enc *encoder) ArgsOf(t reflect.Type) (graphql.FieldConfigArgument, error)
 	if t.Kind() != reflect.Struct {
 		return r, fmt.Errorf("cannot build args from a non struct")
 	}
+	defer enc.rootPath(t.Name())()
 
-	// Goes field by field of the object.
-	for i := 0; i < t.NumField(); i++ {
-		field := t.Field(i)
-		tag, ok := field.Tag.Lookup("graphql")
-		if !ok {
-			// If the field is not tagged, ignore it.
-			continue
-		}
-
-		objectType, ok := enc.getType(field.Type)
-		if !ok {
-			ot, err := enc.buildFieldType(field.Type)
-			if err != nil {
-				return nil, NewErrTypeNotRecognizedWithStruct(err, t, field)
-			}
-			objectType = ot
-			enc.registerType(field.Type, ot)
-		}
-
-		// If the tag starts with "!" it is a NonNull type.
-		if len(tag) > 0 && tag[0] == '!' {
-			objectType = graphql.NewNonNull(objectType)
-			tag = tag[1:]
-		}
-
-		graphQLArgument := &graphql.ArgumentConfig{
-			Type: objectType,
+	// Arguments are input types, so they are built as the fields of an input
+	// object.
+	fields, err := enc.buildInputFields(t)
+	if err != nil {
+		return nil, err
+	}
+	for name, field := range fields {
+		r[name] = &graphql.ArgumentConfig{
+			Type:         field.Type,
+			DefaultValue: field.DefaultValue,
+			Description:  field.Description,
 		}
-		r[tag] = graphQLArgument
 	}
 
 	return r, nil
 }
 
 func (enc *encoder) getType(t reflect.Type) (graphql.Type, bool) {
-	name := t.Name()
 	if t.Kind() == reflect.Ptr {
-		name = t.Elem().Name()
+		t = t.Elem()
 	}
-	gt, ok := enc.types[name]
+	gt, ok := enc.types[t]
 	return gt, ok
 }
 
-func (enc *encoder) registerType(t reflect.Type, r graphql.Type) {
-	name := t.Name()
+func (enc *encoder) registerType(t reflect.Type, r graphql.Type) error {
 	if t.Kind() == reflect.Ptr {
-		name = t.Elem().Name()
+		t = t.Elem()
+	}
+	if err := enc.registerName(t, r); err != nil {
+		return err
+	}
+	enc.types[t] = r
+	enc.onRollback(func() {
+		delete(enc.types, t)
+	})
+	return nil
+}
+
+// pushPath appends the segment to the path of the field being built. The
+// returned function restores the path.
+func (enc *encoder) pushPath(segment string) func() {
+	n := len(enc.path)
+	enc.path = append(enc.path, segment)
+	return func() {
+		enc.path = enc.path[:n]
+	}
+}
+
+// rootPath starts the path with the name of the type, unless a type is
+// already being built. The returned function restores the path.
+func (enc *encoder) rootPath(name string) func() {
+	if len(enc.path) > 0 {
+		return func() {}
 	}
-	enc.types[name] = r
+	return enc.pushPath(name)
+}
+
+// listPath marks the last segment of the path as a list (`items[]`), as the
+// type of its items is being built. The returned function restores it.
+func (enc *encoder) listPath() func() {
+	n := len(enc.path)
+	if n == 0 {
+		return func() {}
+	}
+	segment := enc.path[n-1]
+	enc.path[n-1] += "[]"
+	return func() {
+		enc.path[n-1] = segment
+	}
+}
+
+// fieldError handles the error of the field (named by the segment) of the
+// type being built.
+//
+// When the errors are aggregated, the error is recorded with the path of
+// the field and nil is returned, so the field is skipped. Otherwise, the
+// error is returned as it is.
+func (enc *encoder) fieldError(segment string, t reflect.Type, err error) error {
+	if !enc.config.aggregateErrors {
+		return err
+	}
+	if e, ok := err.(*TypeNotRecognizedWithStructError); ok {
+		// The path already tells the struct and the field.
+		err = e.reason
+	}
+
+	path := strings.Join(append(append([]string{}, enc.path...), segment), ".")
+	for _, e := range enc.errs {
+		// The fields of interfaces are built again when promoted.
+		if e.Path == path && e.Reason.Error() == err.Error() {
+			return nil
+		}
+	}
+	enc.errs = append(enc.errs, &FieldError{
+		Path:   path,
+		Type:   t,
+		Reason: err,
+	})
+	return nil
+}
+
+// endBuild finishes the build of the current call, returning its err along
+// with the errors aggregated.
+func (enc *encoder) endBuild(err error) error {
+	errs, rollback := enc.errs, enc.rollback
+	enc.buildState = buildState{}
+	if err != nil || len(errs) > 0 {
+		// The types built by the call are incomplete (as the objects that
+		// lack the fields skipped), so they are discarded.
+		for i := len(rollback) - 1; i >= 0; i-- {
+			rollback[i]()
+		}
+	}
+	if len(errs) == 0 {
+		return err
+	}
+	if err != nil {
+		errs = append(errs, &FieldError{Reason: err})
+	}
+	return errs
+}
+
+// registerName reserves the name of the GraphQL type built by the encoder
+// for the Go type informed.
+//
+// If the name was already taken by another type, a
+// `*TypeNameConflictError` is returned.
+func (enc *encoder) registerName(t reflect.Type, r graphql.Type) error {
+	switch r.(type) {
+	case *graphql.Object, *graphql.InputObject, *graphql.Enum, *graphql.Interface, *graphql.Union:
+	default:
+		// Scalars and wrapping types are not built by the encoder, so they
+		// can be shared.
+		return nil
+	}
+	if current, ok := enc.names[r.Name()]; ok && current.gt != r {
+		return NewErrTypeNameConflict(r.Name(), current.t, t)
+	}
+	if _, ok := enc.names[r.Name()]; !ok {
+		name := r.Name()
+		enc.onRollback(func() {
+			delete(enc.names, name)
+		})
+	}
+	enc.names[r.Name()] = namedType{
+		t:  t,
+		gt: r,
+	}
+	return nil
+}
+
+// derivedName returns the name of the types derived from t (as its
+// connection or the conditions of its filters). The types built by the
+// encoder are already named with the prefix and the suffix of the encoder,
+// but not the scalars.
+func (enc *encoder) derivedName(t graphql.Type) string {
+	if named, ok := enc.names[t.Name()]; ok && named.gt == t {
+		return t.Name()
+	}
+	return enc.config.typeName(t.Name())
+}
+
+// onRollback registers a function that undoes a change of the caches, called
+// when the current call fails.
+func (enc *encoder) onRollback(undo func()) {
+	enc.rollback = append(enc.rollback, undo)
+}
+
+// lock locks the encoder for a call and returns the function that unlocks
+// it.
+//
+// The hooks called while building (as `GraphqlType`) may use the encoder
+// again, as `gqlstruct.Struct` does with the default encoder. So, when the
+// goroutine already holds the lock, the call is not blocked: it is run as a
+// call of its own, with the state of the current call kept aside.
+func (enc *encoder) lock() func() {
+	id := goroutineID()
+	if atomic.LoadInt64(&enc.owner) == id {
+		state := enc.buildState
+		enc.buildState = buildState{}
+		return func() {
+			enc.buildState = state
+		}
+	}
+	enc.mu.Lock()
+	atomic.StoreInt64(&enc.owner, id)
+	return func() {
+		atomic.StoreInt64(&enc.owner, 0)
+		enc.mu.Unlock()
+	}
+}
+
+// goroutineID returns the id of the current goroutine, which is the first
+// line of its stack trace ("goroutine 1 [running]:").
+func goroutineID() int64 {
+	var buf [64]byte
+	n := runtime.Stack(buf[:], false)
+	id, _ := strconv.ParseInt(string(bytes.Fields(buf[:n])[1]), 10, 64)
+	return id
 }
 
-func Struct(obj interface{}) *graphql.Object {
-	r, err := defaultEncoder.Struct(obj)
+func Struct(obj interface{}, options ...StructOption) *graphql.Object {
+	r, err := defaultEncoder.Struct(obj, options...)
 	if err != nil {
 		panic(err.Error())
 	}
@@ -239,11 +657,11 @@ func ArgsOf(t reflect.Type) graphql.FieldConfigArgument {
 	return r
 }
 
-func FieldOf(t reflect.Type, options ...Option) (graphql.Field, error) {
+func FieldOf(t reflect.Type, options ...FieldOption) (graphql.Field, error) {
 	return defaultEncoder.FieldOf(t, options...)
 }
 
-func Field(t interface{}, options ...Option) graphql.Field {
+func Field(t interface{}, options ...FieldOption) graphql.Field {
 	r, err := defaultEncoder.Field(t, options...)
 	if err != nil {
 		panic(err.Error())
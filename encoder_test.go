@@ -5,6 +5,8 @@ import (
 	"github.com/lab259/go-graphql-struct"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
+	"reflect"
+	"sync"
 )
 
 type CustomFieldType struct {
@@ -27,6 +29,44 @@ func (t *CustomFieldTypeWithResolver) GraphqlType() graphql.Type {
 	return graphql.Float
 }
 
+type ReentrantInner struct {
+	Name string `graphql:"name"`
+}
+
+// ReentrantWrapper builds its type with the default encoder, while the
+// struct that has it as a field is being built.
+type ReentrantWrapper struct{}
+
+func (*ReentrantWrapper) GraphqlType() graphql.Type {
+	return gqlstruct.Struct(&ReentrantInner{})
+}
+
+type ReentrantOuter struct {
+	Inner ReentrantWrapper `graphql:"inner"`
+}
+
+type ReentrantBroken struct {
+	Channel chan int `graphql:"channel"`
+}
+
+var reentrantEncoder = gqlstruct.NewEncoder(gqlstruct.WithAggregatedErrors())
+
+// ReentrantFallback fails to build a type with the encoder building the
+// struct that has it as a field.
+type ReentrantFallback struct{}
+
+func (*ReentrantFallback) GraphqlType() graphql.Type {
+	if _, err := reentrantEncoder.Struct(&ReentrantBroken{}); err != nil {
+		return graphql.String
+	}
+	return graphql.Int
+}
+
+type ReentrantFallbackOuter struct {
+	Value ReentrantFallback `graphql:"value"`
+	Other string            `graphql:"other"`
+}
+
 var _ = Describe("Struct", func() {
 	It("should ignore not tagged fields", func() {
 		type StructExample struct {
@@ -93,4 +133,205 @@ var _ = Describe("Struct", func() {
 		Expect(err.Error()).To(ContainSubstring("not recognized"))
 		Expect(err.Error()).To(ContainSubstring("interface {}"))
 	})
+
+	It("should not mix unnamed types", func() {
+		type StructExample struct {
+			Field1 []string `graphql:"field1"`
+			Field2 []int    `graphql:"field2"`
+			Field3 []bool   `graphql:"field3"`
+		}
+
+		obj, err := gqlstruct.NewEncoder().Struct(&StructExample{})
+		Expect(err).ToNot(HaveOccurred())
+		fields := obj.Fields()
+		Expect(fields["field1"].Type.String()).To(Equal("[String]"))
+		Expect(fields["field2"].Type.String()).To(Equal("[Int]"))
+		Expect(fields["field3"].Type.String()).To(Equal("[Boolean]"))
+	})
+
+	It("should fail when two types have the same name", func() {
+		billingInvoice := func() interface{} {
+			type Invoice struct {
+				Total float64 `graphql:"total"`
+			}
+			return Invoice{}
+		}
+		crmInvoice := func() interface{} {
+			type Invoice struct {
+				Customer string `graphql:"customer"`
+			}
+			return Invoice{}
+		}
+
+		enc := gqlstruct.NewEncoder()
+		_, err := enc.Struct(billingInvoice())
+		Expect(err).ToNot(HaveOccurred())
+		_, err = enc.Struct(crmInvoice())
+		Expect(err).To(HaveOccurred())
+		Expect(err).To(BeAssignableToTypeOf(&gqlstruct.TypeNameConflictError{}))
+		Expect(err.Error()).To(ContainSubstring("'Invoice' is already defined"))
+	})
+
+	It("should fail when a nested type has the same name of a type already built", func() {
+		newOrder := func() interface{} {
+			type Item struct {
+				Name string `graphql:"name"`
+			}
+			type Order struct {
+				Items []Item `graphql:"items"`
+			}
+			return Order{}
+		}
+
+		type Item struct {
+			Price float64 `graphql:"price"`
+		}
+
+		enc := gqlstruct.NewEncoder()
+		_, err := enc.Struct(Item{})
+		Expect(err).ToNot(HaveOccurred())
+		_, err = enc.Struct(newOrder())
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("'Item' is already defined"))
+	})
+
+	It("should fail when an input object has the same name of an object", func() {
+		type AddressInput struct {
+			Street string `graphql:"street"`
+		}
+
+		type Address struct {
+			Street string `graphql:"street"`
+		}
+
+		enc := gqlstruct.NewEncoder()
+		_, err := enc.Struct(AddressInput{})
+		Expect(err).ToNot(HaveOccurred())
+		_, err = enc.InputObject(Address{})
+		Expect(err).To(HaveOccurred())
+		Expect(err).To(BeAssignableToTypeOf(&gqlstruct.TypeNameConflictError{}))
+	})
+})
+
+type ConcurrentPerson struct {
+	Name       string             `graphql:"!name"`
+	BestFriend *ConcurrentPerson  `graphql:"bestFriend"`
+	Friends    []ConcurrentPerson `graphql:"friends"`
+}
+
+type ConcurrentFilter struct {
+	Name   string            `graphql:"name"`
+	Nested *ConcurrentFilter `graphql:"nested"`
+}
+
+type ConcurrentArgs struct {
+	Filter ConcurrentFilter `graphql:"filter"`
+}
+
+// concurrently calls fn from n goroutines at once, returning their results.
+func concurrently(n int, fn func() interface{}) []interface{} {
+	r := make([]interface{}, n)
+	start := make(chan struct{})
+	var wg sync.WaitGroup
+	wg.Add(n)
+	for i := 0; i < n; i++ {
+		go func(i int) {
+			defer wg.Done()
+			defer GinkgoRecover()
+			<-start
+			r[i] = fn()
+		}(i)
+	}
+	close(start)
+	wg.Wait()
+	return r
+}
+
+var _ = Describe("Concurrency", func() {
+	It("should build the same recursive object from several goroutines", func() {
+		enc := gqlstruct.NewEncoder()
+		objs := concurrently(16, func() interface{} {
+			obj, err := enc.StructOf(reflect.TypeOf(ConcurrentPerson{}))
+			Expect(err).ToNot(HaveOccurred())
+			return obj
+		})
+
+		obj := objs[0].(*graphql.Object)
+		for _, o := range objs {
+			Expect(o).To(BeIdenticalTo(obj))
+		}
+		fields := obj.Fields()
+		Expect(fields).To(HaveLen(3))
+		Expect(fields["bestFriend"].Type).To(BeIdenticalTo(obj))
+		Expect(fields["friends"].Type.(*graphql.List).OfType).To(BeIdenticalTo(obj))
+	})
+
+	It("should build arrays and arguments from several goroutines", func() {
+		enc := gqlstruct.NewEncoder()
+		results := concurrently(16, func() interface{} {
+			list, err := enc.ArrayOf(reflect.TypeOf(ConcurrentPerson{}))
+			Expect(err).ToNot(HaveOccurred())
+			args, err := enc.ArgsOf(reflect.TypeOf(ConcurrentArgs{}))
+			Expect(err).ToNot(HaveOccurred())
+			return [2]interface{}{list.(*graphql.List).OfType, args["filter"].Type}
+		})
+
+		first := results[0].([2]interface{})
+		filter := first[1].(*graphql.InputObject)
+		for _, r := range results {
+			Expect(r.([2]interface{})[0]).To(BeIdenticalTo(first[0]))
+			Expect(r.([2]interface{})[1]).To(BeIdenticalTo(filter))
+		}
+		Expect(filter.Fields()["nested"].Type).To(BeIdenticalTo(filter))
+	})
+
+	It("should build schemas and list the types from several goroutines", func() {
+		enc := gqlstruct.NewEncoder()
+		concurrently(8, func() interface{} {
+			_, err := enc.Struct(ConcurrentPerson{})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(enc.PrintSDL()).To(ContainSubstring("type ConcurrentPerson"))
+			return nil
+		})
+		Expect(enc.Types()).To(HaveLen(1))
+	})
+
+	It("should build types with the default encoder from several goroutines", func() {
+		objs := concurrently(16, func() interface{} {
+			return gqlstruct.Struct(ConcurrentPerson{})
+		})
+		for _, o := range objs {
+			Expect(o).To(BeIdenticalTo(objs[0]))
+		}
+	})
+})
+
+var _ = Describe("Reentrancy", func() {
+	It("should build the types of the hooks that use the encoder being used", func() {
+		done := make(chan *graphql.Object)
+		go func() {
+			defer GinkgoRecover()
+			done <- gqlstruct.Struct(&ReentrantOuter{})
+		}()
+
+		var obj *graphql.Object
+		Eventually(done).Should(Receive(&obj))
+		Expect(obj.Fields()["inner"].Type).To(BeIdenticalTo(gqlstruct.Struct(&ReentrantInner{})))
+	})
+
+	It("should keep the errors of the calls of the hooks apart", func() {
+		done := make(chan error)
+		var obj *graphql.Object
+		go func() {
+			defer GinkgoRecover()
+			var err error
+			obj, err = reentrantEncoder.Struct(&ReentrantFallbackOuter{})
+			done <- err
+		}()
+
+		Eventually(done).Should(Receive(BeNil()))
+		Expect(obj.Fields()).To(HaveLen(2))
+		Expect(obj.Fields()["value"].Type).To(Equal(graphql.String))
+		Expect(reentrantEncoder.Types()).To(HaveLen(1))
+	})
 })
@@ -0,0 +1,162 @@
+package gqlstruct
+
+import (
+	"fmt"
+	"github.com/graphql-go/graphql"
+	"reflect"
+)
+
+// GraphqlEnum is the interface implemented by types that will be described
+// as a `graphql.Enum`.
+//
+// Go does not provide a way of listing the constants of a type, so they must
+// be informed by this interface (or by the `RegisterEnum` method).
+type GraphqlEnum interface {
+	// GraphqlEnumValues returns the values of the enum that implements this
+	// interface.
+	GraphqlEnumValues() []EnumValue
+}
+
+var graphqlEnumType = reflect.TypeOf(new(GraphqlEnum)).Elem()
+
+// EnumValue describes a value of an enum.
+type EnumValue struct {
+	value   interface{}
+	options []EnumValueOption
+}
+
+// NewEnumValue creates an `EnumValue` based on a constant.
+//
+// The name of the value is provided by its `String()` method (for string
+// based types, the value itself is used).
+//
+// The options informed are applied to the `*graphql.EnumValueConfig`. So,
+// descriptions and deprecation reasons can be defined for each value.
+func NewEnumValue(value interface{}, options ...EnumValueOption) EnumValue {
+	return EnumValue{
+		value:   value,
+		options: options,
+	}
+}
+
+// name returns the name that will represent the value of the enum.
+func (value EnumValue) name() (string, error) {
+	if s, ok := value.value.(fmt.Stringer); ok {
+		return s.String(), nil
+	}
+	v := reflect.ValueOf(value.value)
+	if v.Kind() == reflect.String {
+		return v.String(), nil
+	}
+	return "", fmt.Errorf("the enum value %v does not implement fmt.Stringer", value.value)
+}
+
+// RegisterEnum builds a `*graphql.Enum` with the values informed and
+// registers it to the type of the obj. From now on, every field of that type
+// will be described as the enum.
+func (enc *encoder) RegisterEnum(obj interface{}, values []EnumValue, options ...ObjectOption) (*graphql.Enum, error) {
+	defer enc.lock()()
+	r, err := enc.registerEnum(obj, values, options...)
+	if err := enc.endBuild(err); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// registerEnum implements `RegisterEnum`, without locking the encoder.
+func (enc *encoder) registerEnum(obj interface{}, values []EnumValue, options ...ObjectOption) (*graphql.Enum, error) {
+	t := reflect.TypeOf(obj)
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	if r, ok := enc.getType(t); ok {
+		return nil, fmt.Errorf("%s was already registered as %s", t, r)
+	}
+
+	enumCfg := graphql.EnumConfig{
+		Name:   enc.config.typeName(t.Name()),
+		Values: graphql.EnumValueConfigMap{},
+	}
+
+	for _, value := range values {
+		if reflect.TypeOf(value.value) != t {
+			return nil, fmt.Errorf("the enum value %v is not a %s", value.value, t)
+		}
+		name, err := value.name()
+		if err != nil {
+			return nil, err
+		}
+		valueCfg := &graphql.EnumValueConfig{
+			Value: value.value,
+		}
+		for _, opt := range value.options {
+			err := opt.ApplyEnumValue(valueCfg)
+			if err != nil {
+				return nil, err
+			}
+		}
+		enumCfg.Values[name] = valueCfg
+	}
+
+	// Apply options
+	var err error
+	enumCfg.Name, enumCfg.Description, err = applyTypeOptions(enumCfg.Name, options)
+	if err != nil {
+		return nil, err
+	}
+
+	r := graphql.NewEnum(enumCfg)
+	if err := r.Error(); err != nil {
+		return nil, err
+	}
+	// Enums are valid as outputs and inputs.
+	if err := enc.registerType(t, r); err != nil {
+		return nil, err
+	}
+	if err := enc.registerInputType(t, r); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// EnumOf returns the `*graphql.Enum` registered for the type informed.
+//
+// If the type was not registered, but it implements the `GraphqlEnum`
+// interface, the enum is built and registered.
+func (enc *encoder) EnumOf(t reflect.Type, options ...ObjectOption) (*graphql.Enum, error) {
+	defer enc.lock()()
+	r, err := enc.enumOf(t, options...)
+	if err := enc.endBuild(err); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// enumOf implements `EnumOf`, without locking the encoder.
+func (enc *encoder) enumOf(t reflect.Type, options ...ObjectOption) (*graphql.Enum, error) {
+	if r, ok := enc.getType(t); ok {
+		if d, ok := r.(*graphql.Enum); ok {
+			return d, nil
+		}
+		return nil, fmt.Errorf("%s is not an graphql.Enum", r)
+	}
+
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	if !reflect.PtrTo(t).Implements(graphqlEnumType) {
+		return nil, fmt.Errorf("%s does not implement GraphqlEnum", t)
+	}
+	values := reflect.New(t).Interface().(GraphqlEnum).GraphqlEnumValues()
+	return enc.registerEnum(reflect.Zero(t).Interface(), values, options...)
+}
+
+func RegisterEnum(obj interface{}, values []EnumValue, options ...ObjectOption) *graphql.Enum {
+	r, err := defaultEncoder.RegisterEnum(obj, values, options...)
+	if err != nil {
+		panic(err.Error())
+	}
+	return r
+}
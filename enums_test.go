@@ -0,0 +1,196 @@
+package gqlstruct_test
+
+import (
+	"github.com/graphql-go/graphql"
+	"github.com/lab259/go-graphql-struct"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"reflect"
+)
+
+type Status int
+
+const (
+	StatusActive Status = iota + 1
+	StatusBlocked
+	StatusRemoved
+)
+
+func (s Status) String() string {
+	switch s {
+	case StatusActive:
+		return "ACTIVE"
+	case StatusBlocked:
+		return "BLOCKED"
+	case StatusRemoved:
+		return "REMOVED"
+	}
+	return "UNKNOWN"
+}
+
+func (Status) GraphqlEnumValues() []gqlstruct.EnumValue {
+	return []gqlstruct.EnumValue{
+		gqlstruct.NewEnumValue(StatusActive, gqlstruct.WithDescription("The user can sign in.")),
+		gqlstruct.NewEnumValue(StatusBlocked),
+		gqlstruct.NewEnumValue(StatusRemoved, gqlstruct.WithDeprecationReason("Users are not removed anymore.")),
+	}
+}
+
+type Color string
+
+const (
+	ColorRed   Color = "RED"
+	ColorGreen Color = "GREEN"
+)
+
+type Weekday int
+
+var _ = Describe("Enum", func() {
+	type Account struct {
+		Name      string  `graphql:"name"`
+		Status    Status  `graphql:"!status"`
+		StatusPtr *Status `graphql:"statusPtr"`
+	}
+
+	type AccountArgs struct {
+		Status   Status   `graphql:"status"`
+		Statuses []Status `graphql:"statuses"`
+	}
+
+	It("should generate an enum from a GraphqlEnum", func() {
+		enum, err := gqlstruct.NewEncoder().EnumOf(reflect.TypeOf(StatusActive))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(enum.Name()).To(Equal("Status"))
+		values := enum.Values()
+		Expect(values).To(HaveLen(3))
+		byName := map[string]*graphql.EnumValueDefinition{}
+		for _, value := range values {
+			byName[value.Name] = value
+		}
+		Expect(byName).To(HaveKey("ACTIVE"))
+		Expect(byName["ACTIVE"].Value).To(Equal(StatusActive))
+		Expect(byName["ACTIVE"].Description).To(Equal("The user can sign in."))
+		Expect(byName).To(HaveKey("BLOCKED"))
+		Expect(byName["BLOCKED"].Value).To(Equal(StatusBlocked))
+		Expect(byName).To(HaveKey("REMOVED"))
+		Expect(byName["REMOVED"].DeprecationReason).To(Equal("Users are not removed anymore."))
+	})
+
+	It("should use the enum for fields and arguments", func() {
+		enc := gqlstruct.NewEncoder()
+		obj, err := enc.Struct(Account{})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(obj.Fields()["status"].Type.String()).To(Equal("Status!"))
+		Expect(obj.Fields()["statusPtr"].Type).To(BeAssignableToTypeOf(&graphql.Enum{}))
+
+		args, err := enc.ArgsOf(reflect.TypeOf(AccountArgs{}))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(args["status"].Type).To(Equal(obj.Fields()["statusPtr"].Type))
+		Expect(args["statuses"].Type.String()).To(Equal("[Status]"))
+	})
+
+	It("should register an enum from constants", func() {
+		enc := gqlstruct.NewEncoder()
+		enum, err := enc.RegisterEnum(ColorRed, []gqlstruct.EnumValue{
+			gqlstruct.NewEnumValue(ColorRed),
+			gqlstruct.NewEnumValue(ColorGreen),
+		}, gqlstruct.WithDescription("Description 1"))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(enum.Name()).To(Equal("Color"))
+		Expect(enum.Description()).To(Equal("Description 1"))
+		Expect(enum.Values()).To(HaveLen(2))
+
+		type Paint struct {
+			Color Color `graphql:"color"`
+		}
+
+		obj, err := enc.Struct(Paint{})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(obj.Fields()["color"].Type).To(Equal(enum))
+	})
+
+	It("should fail registering a value of another type", func() {
+		_, err := gqlstruct.NewEncoder().RegisterEnum(ColorRed, []gqlstruct.EnumValue{
+			gqlstruct.NewEnumValue(StatusActive),
+		})
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("is not a gqlstruct_test.Color"))
+	})
+
+	It("should fail registering a value without a name", func() {
+		_, err := gqlstruct.NewEncoder().RegisterEnum(Weekday(0), []gqlstruct.EnumValue{
+			gqlstruct.NewEnumValue(Weekday(0)),
+		})
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("does not implement fmt.Stringer"))
+	})
+
+	It("should fail registering an enum twice", func() {
+		enc := gqlstruct.NewEncoder()
+		_, err := enc.EnumOf(reflect.TypeOf(StatusActive))
+		Expect(err).ToNot(HaveOccurred())
+		_, err = enc.RegisterEnum(StatusActive, nil)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("was already registered"))
+	})
+
+	It("should fail building an enum from a type that is not an enum", func() {
+		_, err := gqlstruct.NewEncoder().EnumOf(reflect.TypeOf(Weekday(0)))
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("does not implement GraphqlEnum"))
+	})
+
+	It("should serialize and parse enum values", func() {
+		enc := gqlstruct.NewEncoder()
+		accountType, err := enc.Struct(Account{})
+		Expect(err).ToNot(HaveOccurred())
+		args, err := enc.ArgsOf(reflect.TypeOf(AccountArgs{}))
+		Expect(err).ToNot(HaveOccurred())
+
+		var received []interface{}
+		schema, err := graphql.NewSchema(graphql.SchemaConfig{
+			Query: graphql.NewObject(graphql.ObjectConfig{
+				Name: "Query",
+				Fields: graphql.Fields{
+					"accounts": &graphql.Field{
+						Type: graphql.NewList(accountType),
+						Args: args,
+						Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+							received = append(received, p.Args["status"])
+							received = append(received, p.Args["statuses"].([]interface{})...)
+							blocked := StatusBlocked
+							return []Account{
+								{Name: "John", Status: p.Args["status"].(Status), StatusPtr: &blocked},
+							}, nil
+						},
+					},
+				},
+			}),
+		})
+		Expect(err).ToNot(HaveOccurred())
+
+		r := graphql.Do(graphql.Params{
+			Schema:        schema,
+			RequestString: `{ accounts(status: ACTIVE, statuses: [BLOCKED, REMOVED]) { name status statusPtr } }`,
+		})
+		Expect(r.Errors).To(BeEmpty())
+		Expect(received).To(Equal([]interface{}{StatusActive, StatusBlocked, StatusRemoved}))
+		Expect(r.Data).To(Equal(map[string]interface{}{
+			"accounts": []interface{}{
+				map[string]interface{}{
+					"name":      "John",
+					"status":    "ACTIVE",
+					"statusPtr": "BLOCKED",
+				},
+			},
+		}))
+	})
+
+	It("should panic registering an invalid enum using the global encoder", func() {
+		Expect(func() {
+			gqlstruct.RegisterEnum(Weekday(0), []gqlstruct.EnumValue{
+				gqlstruct.NewEnumValue(Weekday(0)),
+			})
+		}).To(Panic())
+	})
+})
@@ -3,18 +3,26 @@ package gqlstruct
 import (
 	"fmt"
 	"reflect"
+	"strings"
 )
 
-type typeNotRecognizedError struct {
+// TypeNotRecognizedError is returned when a Go type cannot be described as a
+// GraphQL type.
+type TypeNotRecognizedError struct {
 	t reflect.Type
 }
 
-func (err *typeNotRecognizedError) Error() string {
+func (err *TypeNotRecognizedError) Error() string {
 	return fmt.Sprintf("'%s' not recognized", err.t)
 }
 
+// Type returns the Go type that was not recognized.
+func (err *TypeNotRecognizedError) Type() reflect.Type {
+	return err.t
+}
+
 func NewErrTypeNotRecognized(t reflect.Type) error {
-	return &typeNotRecognizedError{
+	return &TypeNotRecognizedError{
 		t: t,
 	}
 }
@@ -29,6 +37,10 @@ func (err *TypeNotRecognizedWithStructError) Error() string {
 	return fmt.Sprintf("%s.%s:%s", err.structType.Name(), err.fieldStruct.Name, err.reason.Error())
 }
 
+func (err *TypeNotRecognizedWithStructError) Unwrap() error {
+	return err.reason
+}
+
 func NewErrTypeNotRecognizedWithStruct(reason error, structType reflect.Type, structField reflect.StructField) error {
 	return &TypeNotRecognizedWithStructError{
 		reason:      reason,
@@ -36,3 +48,143 @@ func NewErrTypeNotRecognizedWithStruct(reason error, structType reflect.Type, st
 		fieldStruct: structField,
 	}
 }
+
+type TypeNameConflictError struct {
+	name     string
+	existing reflect.Type
+	t        reflect.Type
+}
+
+func (err *TypeNameConflictError) Error() string {
+	return fmt.Sprintf("'%s' is already defined by '%s', it cannot be redefined by '%s'", err.name, typeFullName(err.existing), typeFullName(err.t))
+}
+
+func NewErrTypeNameConflict(name string, existing, t reflect.Type) error {
+	return &TypeNameConflictError{
+		name:     name,
+		existing: existing,
+		t:        t,
+	}
+}
+
+// typeFullName returns the name of the type along with its package path, so
+// types with the same name from different packages can be told apart.
+func typeFullName(t reflect.Type) string {
+	if t.PkgPath() == "" {
+		return t.String()
+	}
+	return t.PkgPath() + "." + t.Name()
+}
+
+type InvalidTagError struct {
+	reason      error
+	structType  reflect.Type
+	fieldStruct reflect.StructField
+	tag         string
+}
+
+func (err *InvalidTagError) Error() string {
+	return fmt.Sprintf("%s.%s: invalid tag `%s`: %s", err.structType.Name(), err.fieldStruct.Name, err.tag, err.reason.Error())
+}
+
+func (err *InvalidTagError) Unwrap() error {
+	return err.reason
+}
+
+func NewErrInvalidTag(reason error, structType reflect.Type, structField reflect.StructField) error {
+	return newErrInvalidTag(reason, structType, structField, structField.Tag.Get(defaultTagKey))
+}
+
+// newErrInvalidTag creates an `*InvalidTagError` with the tag that described
+// the field, as it may come from another key or be derived from its name.
+func newErrInvalidTag(reason error, structType reflect.Type, structField reflect.StructField, tag string) error {
+	return &InvalidTagError{
+		reason:      reason,
+		structType:  structType,
+		fieldStruct: structField,
+		tag:         tag,
+	}
+}
+
+type DecodeError struct {
+	path   string
+	value  interface{}
+	t      reflect.Type
+	reason error
+}
+
+func (err *DecodeError) Error() string {
+	msg := fmt.Sprintf("%s: cannot use %v (%T) as %s", err.path, err.value, err.value, err.t)
+	if err.reason != nil {
+		msg += ": " + err.reason.Error()
+	}
+	return msg
+}
+
+func (err *DecodeError) Unwrap() error {
+	return err.reason
+}
+
+func NewErrDecode(path string, value interface{}, t reflect.Type, reason error) error {
+	return &DecodeError{
+		path:   path,
+		value:  value,
+		t:      t,
+		reason: reason,
+	}
+}
+
+// FieldError is an error of a field found while building a type, when the
+// errors are aggregated (check `WithAggregatedErrors`).
+type FieldError struct {
+	// Path is the path of the field from the type being built, using the
+	// GraphQL names of the fields (eg. `Order.items[].product.price`). It
+	// is empty when the error is not specific to a field.
+	Path string
+	// Type is the Go type of the field. It is nil when the field is not
+	// described by a Go field (as the options of a field not defined).
+	Type reflect.Type
+	// Reason is the error found.
+	Reason error
+}
+
+func (err *FieldError) Error() string {
+	if err.Path == "" {
+		return err.Reason.Error()
+	}
+	if err.Type == nil {
+		return fmt.Sprintf("%s: %s", err.Path, err.Reason.Error())
+	}
+	return fmt.Sprintf("%s (%s): %s", err.Path, err.Type, err.Reason.Error())
+}
+
+func (err *FieldError) Unwrap() error {
+	return err.Reason
+}
+
+// SchemaErrors are all errors found while building a type, when the errors
+// are aggregated (check `WithAggregatedErrors`).
+type SchemaErrors []*FieldError
+
+func (errs SchemaErrors) Error() string {
+	msgs := make([]string, len(errs))
+	for i, err := range errs {
+		msgs[i] = "\n* " + err.Error()
+	}
+	return fmt.Sprintf("%d errors found:%s", len(errs), strings.Join(msgs, ""))
+}
+
+// Errors returns the errors found, in the order they were found.
+func (errs SchemaErrors) Errors() []error {
+	r := make([]error, len(errs))
+	for i, err := range errs {
+		r[i] = err
+	}
+	return r
+}
+
+// Unwrap returns the errors, so `errors.Is` and `errors.As` check each one
+// of them (since Go 1.20).
+func (errs SchemaErrors) Unwrap() []error {
+	return errs.Errors()
+}
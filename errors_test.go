@@ -0,0 +1,160 @@
+package gqlstruct_test
+
+import (
+	"errors"
+	"github.com/lab259/go-graphql-struct"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"reflect"
+)
+
+type BrokenProduct struct {
+	Name  string     `graphql:"name"`
+	Price func() int `graphql:"price"`
+}
+
+type BrokenItem struct {
+	Product *BrokenProduct `graphql:"product"`
+}
+
+type BrokenBuyer struct {
+	Email string `graphql:"email,foo"`
+}
+
+type BrokenOrderArgs struct {
+	Status chan int `graphql:"status"`
+}
+
+type BrokenOrder struct {
+	ID    string       `graphql:"!id"`
+	Items []BrokenItem `graphql:"items"`
+	Buyer BrokenBuyer  `graphql:"buyer"`
+}
+
+func (o *BrokenOrder) GraphqlMethods() map[string]string {
+	return map[string]string{
+		"Related": "related",
+	}
+}
+
+func (o *BrokenOrder) Related(args BrokenOrderArgs) []string {
+	return nil
+}
+
+var _ = Describe("Errors", func() {
+	It("should fail on the first error by default", func() {
+		_, err := gqlstruct.NewEncoder().Struct(BrokenOrder{})
+		Expect(err).To(HaveOccurred())
+		Expect(err).To(BeAssignableToTypeOf(&gqlstruct.TypeNotRecognizedWithStructError{}))
+	})
+
+	It("should aggregate the errors of all fields", func() {
+		enc := gqlstruct.NewEncoder(gqlstruct.WithAggregatedErrors())
+		_, err := enc.Struct(BrokenOrder{})
+		Expect(err).To(HaveOccurred())
+		Expect(err).To(BeAssignableToTypeOf(gqlstruct.SchemaErrors{}))
+
+		errs := err.(gqlstruct.SchemaErrors)
+		Expect(errs).To(HaveLen(3))
+
+		Expect(errs[0].Path).To(Equal("BrokenOrder.items[].product.price"))
+		Expect(errs[0].Type).To(Equal(reflect.TypeOf(func() int { return 0 })))
+		Expect(errs[0].Reason).To(BeAssignableToTypeOf(&gqlstruct.TypeNotRecognizedError{}))
+
+		Expect(errs[1].Path).To(Equal("BrokenOrder.buyer.Email"))
+		Expect(errs[1].Reason).To(BeAssignableToTypeOf(&gqlstruct.InvalidTagError{}))
+
+		Expect(errs[2].Path).To(Equal("BrokenOrder.related.status"))
+		Expect(errs[2].Type).To(Equal(reflect.TypeOf(make(chan int))))
+
+		Expect(err.Error()).To(HavePrefix("3 errors found:\n* BrokenOrder.items[].product.price (func() int): 'func() int' not recognized\n"))
+	})
+
+	It("should support errors.As on the aggregated errors", func() {
+		_, err := gqlstruct.NewEncoder(gqlstruct.WithAggregatedErrors()).Struct(BrokenOrder{})
+
+		var notRecognized *gqlstruct.TypeNotRecognizedError
+		Expect(errors.As(err, &notRecognized)).To(BeTrue())
+		Expect(notRecognized.Type()).To(Equal(reflect.TypeOf(func() int { return 0 })))
+
+		var invalidTag *gqlstruct.InvalidTagError
+		Expect(errors.As(err, &invalidTag)).To(BeTrue())
+	})
+
+	It("should list the aggregated errors", func() {
+		_, err := gqlstruct.NewEncoder(gqlstruct.WithAggregatedErrors()).Struct(BrokenOrder{})
+		errs := err.(gqlstruct.SchemaErrors)
+
+		list := errs.Errors()
+		Expect(list).To(HaveLen(3))
+		for i, e := range list {
+			Expect(e).To(BeIdenticalTo(errs[i]))
+		}
+	})
+
+	It("should support errors.As on the errors of the first field", func() {
+		_, err := gqlstruct.NewEncoder().Struct(BrokenProduct{})
+
+		var notRecognized *gqlstruct.TypeNotRecognizedError
+		Expect(errors.As(err, &notRecognized)).To(BeTrue())
+		Expect(notRecognized.Type().Kind()).To(Equal(reflect.Func))
+	})
+
+	It("should aggregate the errors of all roots of the schema", func() {
+		type BrokenQuery struct {
+			Order BrokenOrder `graphql:"order"`
+		}
+
+		type BrokenMutation struct {
+			Product BrokenProduct `graphql:"product"`
+			Map     map[int]int   `graphql:"map"`
+		}
+
+		enc := gqlstruct.NewEncoder(gqlstruct.WithAggregatedErrors())
+		_, err := enc.Schema(&BrokenQuery{}, &BrokenMutation{}, nil)
+		Expect(err).To(HaveOccurred())
+
+		errs := err.(gqlstruct.SchemaErrors)
+		paths := make([]string, len(errs))
+		for i, err := range errs {
+			paths[i] = err.Path
+		}
+		Expect(paths).To(Equal([]string{
+			"BrokenQuery.order.items[].product.price",
+			"BrokenQuery.order.buyer.Email",
+			"BrokenQuery.order.related.status",
+			"BrokenMutation.map",
+		}))
+	})
+
+	It("should not report the errors of a previous call", func() {
+		enc := gqlstruct.NewEncoder(gqlstruct.WithAggregatedErrors())
+		_, err := enc.Struct(BrokenProduct{})
+		Expect(err).To(HaveOccurred())
+
+		type Valid struct {
+			Name string `graphql:"name"`
+		}
+		_, err = enc.Struct(Valid{})
+		Expect(err).ToNot(HaveOccurred())
+	})
+	It("should not keep the types of a call that failed", func() {
+		enc := gqlstruct.NewEncoder(gqlstruct.WithAggregatedErrors())
+		for i := 0; i < 2; i++ {
+			_, err := enc.Struct(BrokenItem{})
+			Expect(err).To(HaveOccurred())
+			Expect(err.(gqlstruct.SchemaErrors)).To(HaveLen(1))
+			Expect(err.(gqlstruct.SchemaErrors)[0].Path).To(Equal("BrokenItem.product.price"))
+		}
+		Expect(enc.Types()).To(BeEmpty())
+	})
+
+	It("should not keep the types of a call that failed on the first error", func() {
+		enc := gqlstruct.NewEncoder()
+		for i := 0; i < 2; i++ {
+			_, err := enc.Struct(BrokenItem{})
+			Expect(err).To(HaveOccurred())
+		}
+		Expect(enc.Types()).To(BeEmpty())
+	})
+})
@@ -2,7 +2,6 @@ package main
 
 import (
 	"fmt"
-	"github.com/graphql-go/graphql"
 	"github.com/lab259/go-graphql-struct"
 	"github.com/lab259/graphql-fasthttp-handler"
 	"github.com/valyala/fasthttp"
@@ -14,37 +13,35 @@ type Person struct {
 	Friends []Person `graphql:"friends"`
 }
 
-// main will initialize the schema and start a HTTP server on port 8080.
-func main() {
-	// It creates the schema
-	schema, err := graphql.NewSchema(graphql.SchemaConfig{
-		Query: graphql.NewObject(graphql.ObjectConfig{
-			Name: "Query",
-			Fields: graphql.Fields{
-				"hero": &graphql.Field{
-					Type: gqlstruct.Struct(&Person{}),
-					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
-						return &Person{
-							Name: "Snake Eyes",
-							Age:  1,
-							Friends: []Person{
-								{
-									Name: "Scarlett",
-								},
-								{
-									Name: "Duke",
-								},
-							},
-						}, nil
-					},
-				},
-			},
-		}), // Create the type based on the Query{} struct
-	})
+// Query is the root of the queries. Its methods listed by `GraphqlMethods`
+// become the root fields.
+type Query struct{}
 
-	if err != nil {
-		panic(err)
+func (q *Query) GraphqlMethods() map[string]string {
+	return map[string]string{
+		"Hero": "hero",
 	}
+}
+
+func (q *Query) Hero() *Person {
+	return &Person{
+		Name: "Snake Eyes",
+		Age:  1,
+		Friends: []Person{
+			{
+				Name: "Scarlett",
+			},
+			{
+				Name: "Duke",
+			},
+		},
+	}
+}
+
+// main will initialize the schema and start a HTTP server on port 8080.
+func main() {
+	// It creates the schema based on the Query{} struct
+	schema := gqlstruct.Schema(&Query{}, nil, nil)
 
 	// Create the handler
 	h := handler.New(&handler.Config{
@@ -56,7 +53,7 @@ func main() {
 
 	fmt.Println("Starting the server at 8080 ...")
 	// Start the fasthttp server.
-	err = fasthttp.ListenAndServe(":8080", h.ServeHTTP)
+	err := fasthttp.ListenAndServe(":8080", h.ServeHTTP)
 	if err != nil {
 		panic(err)
 	}
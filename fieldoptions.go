@@ -0,0 +1,96 @@
+package gqlstruct
+
+import (
+	"fmt"
+	"github.com/graphql-go/graphql"
+	"reflect"
+	"sort"
+)
+
+// GraphqlFieldOptions is the interface implemented by structs that apply
+// options to their fields.
+type GraphqlFieldOptions interface {
+	// GraphqlFieldOptions returns the options of the fields, indexed by the
+	// name of the GraphQL field:
+	//
+	// ```
+	// func (u *User) GraphqlFieldOptions() map[string][]gqlstruct.FieldOption {
+	//     return map[string][]gqlstruct.FieldOption{
+	//         "email": {gqlstruct.WithDescription("The email used to sign in.")},
+	//     }
+	// }
+	// ```
+	GraphqlFieldOptions() map[string][]FieldOption
+}
+
+var graphqlFieldOptionsType = reflect.TypeOf(new(GraphqlFieldOptions)).Elem()
+
+type withFieldOptions struct {
+	field   string
+	options []FieldOption
+}
+
+// WithFieldOptions creates a `StructOption` that applies the options to a
+// field of the struct, identified by its GraphQL name:
+//
+// ```
+// gqlstruct.Struct(User{}, gqlstruct.WithFieldOptions("email", gqlstruct.WithResolve(resolveEmail)))
+// ```
+//
+// The options are applied after the ones from `GraphqlFieldOptions`, to the
+// fields built from the tags and from the methods of the struct.
+func WithFieldOptions(field string, options ...FieldOption) StructOption {
+	return &withFieldOptions{
+		field:   field,
+		options: options,
+	}
+}
+
+// ApplyStruct adds the options to the options of the field.
+func (option *withFieldOptions) ApplyStruct(cfg *StructConfig) error {
+	if cfg.FieldOptions == nil {
+		cfg.FieldOptions = make(map[string][]FieldOption)
+	}
+	cfg.FieldOptions[option.field] = append(cfg.FieldOptions[option.field], option.options...)
+	return nil
+}
+
+// structFieldOptions returns the options of the fields of the struct, from
+// the `GraphqlFieldOptions` interface.
+func structFieldOptions(t reflect.Type) map[string][]FieldOption {
+	options := make(map[string][]FieldOption)
+	if reflect.PtrTo(t).Implements(graphqlFieldOptionsType) {
+		for field, fieldOptions := range reflect.New(t).Interface().(GraphqlFieldOptions).GraphqlFieldOptions() {
+			options[field] = append(options[field], fieldOptions...)
+		}
+	}
+	return options
+}
+
+// applyStructFieldOptions applies the options to the fields of the struct,
+// indexed by the name of the field.
+func (enc *encoder) applyStructFieldOptions(t reflect.Type, fields graphql.Fields, options map[string][]FieldOption) error {
+	// The fields are sorted, so the errors are always reported in the same
+	// order.
+	names := make([]string, 0, len(options))
+	for name := range options {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		field, ok := fields[name]
+		if !ok {
+			if err := enc.fieldError(name, nil, fmt.Errorf("%s: the field '%s' is not defined", t.Name(), name)); err != nil {
+				return err
+			}
+			continue
+		}
+		if err := enc.applyFieldOptions(field, options[name]); err != nil {
+			if err := enc.fieldError(name, nil, fmt.Errorf("%s.%s: %s", t.Name(), name, err.Error())); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
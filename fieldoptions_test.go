@@ -0,0 +1,145 @@
+package gqlstruct_test
+
+import (
+	"github.com/graphql-go/graphql"
+	"github.com/lab259/go-graphql-struct"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"strings"
+)
+
+type FieldOptionsArgs struct {
+	Upper bool `graphql:"upper"`
+}
+
+type FieldOptionsUser struct {
+	Name  string `graphql:"name"`
+	Email string `graphql:"email"`
+}
+
+func (u *FieldOptionsUser) GraphqlFieldOptions() map[string][]gqlstruct.FieldOption {
+	return map[string][]gqlstruct.FieldOption{
+		"name": {
+			gqlstruct.WithDescription("The name of the user."),
+			gqlstruct.WithArgs(FieldOptionsArgs{}),
+			gqlstruct.WithResolve(func(p graphql.ResolveParams) (interface{}, error) {
+				name := p.Source.(*FieldOptionsUser).Name
+				if p.Args["upper"] == true {
+					return strings.ToUpper(name), nil
+				}
+				return name, nil
+			}),
+		},
+	}
+}
+
+var _ = Describe("Field options", func() {
+	It("should apply the options of the GraphqlFieldOptions method", func() {
+		userType, err := gqlstruct.NewEncoder().Struct(FieldOptionsUser{})
+		Expect(err).ToNot(HaveOccurred())
+
+		name := userType.Fields()["name"]
+		Expect(name.Description).To(Equal("The name of the user."))
+		Expect(name.Args).To(HaveLen(1))
+		Expect(name.Args[0].Name()).To(Equal("upper"))
+
+		schema, err := graphql.NewSchema(graphql.SchemaConfig{
+			Query: graphql.NewObject(graphql.ObjectConfig{
+				Name: "Query",
+				Fields: graphql.Fields{
+					"user": &graphql.Field{
+						Type: userType,
+						Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+							return &FieldOptionsUser{Name: "Jane", Email: "jane@example.com"}, nil
+						},
+					},
+				},
+			}),
+		})
+		Expect(err).ToNot(HaveOccurred())
+
+		r := graphql.Do(graphql.Params{
+			Schema:        schema,
+			RequestString: `{ user { name(upper: true) email } }`,
+		})
+		Expect(r.Errors).To(BeEmpty())
+		Expect(r.Data).To(Equal(map[string]interface{}{
+			"user": map[string]interface{}{
+				"name":  "JANE",
+				"email": "jane@example.com",
+			},
+		}))
+	})
+
+	It("should apply the options informed to the struct", func() {
+		obj, err := gqlstruct.NewEncoder().Struct(FieldOptionsUser{},
+			gqlstruct.WithFieldOptions("email",
+				gqlstruct.WithDescription("The email used to sign in."),
+				gqlstruct.WithDeprecationReason("Use the login."),
+			),
+			gqlstruct.WithFieldOptions("name", gqlstruct.WithDescription("The full name.")),
+		)
+		Expect(err).ToNot(HaveOccurred())
+
+		fields := obj.Fields()
+		Expect(fields["email"].Description).To(Equal("The email used to sign in."))
+		Expect(fields["email"].DeprecationReason).To(Equal("Use the login."))
+		// The options informed are applied after the ones from the method.
+		Expect(fields["name"].Description).To(Equal("The full name."))
+		Expect(fields["name"].Args).To(HaveLen(1))
+	})
+
+	It("should apply the options to the fields of the methods", func() {
+		obj, err := gqlstruct.NewEncoder().Struct(MethodUser{},
+			gqlstruct.WithFieldOptions("fullName", gqlstruct.WithDescription("The name.")),
+		)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(obj.Fields()["fullName"].Description).To(Equal("The name."))
+	})
+
+	It("should fail when the field is not defined", func() {
+		_, err := gqlstruct.NewEncoder().Struct(MethodUser{},
+			gqlstruct.WithFieldOptions("unknown", gqlstruct.WithDescription("The description.")),
+		)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(Equal("MethodUser: the field 'unknown' is not defined"))
+	})
+
+	It("should fail when an option fails", func() {
+		_, err := gqlstruct.NewEncoder().Struct(MethodUser{},
+			gqlstruct.WithFieldOptions("firstName", &erroredOption{}),
+		)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(Equal("MethodUser.firstName: forced error"))
+	})
+
+	It("should aggregate the errors of the options", func() {
+		_, err := gqlstruct.NewEncoder(gqlstruct.WithAggregatedErrors()).Struct(MethodUser{},
+			gqlstruct.WithFieldOptions("unknown", gqlstruct.WithDescription("The description.")),
+			gqlstruct.WithFieldOptions("firstName", &erroredOption{}),
+		)
+		Expect(err).To(HaveOccurred())
+
+		errs := err.(gqlstruct.SchemaErrors)
+		Expect(errs).To(HaveLen(2))
+		Expect(errs[0].Path).To(Equal("MethodUser.firstName"))
+		Expect(errs[1].Path).To(Equal("MethodUser.unknown"))
+		Expect(errs[1].Type).To(BeNil())
+		Expect(errs[1].Error()).To(Equal("MethodUser.unknown: MethodUser: the field 'unknown' is not defined"))
+	})
+
+	It("should apply the options to the structs of the default encoder", func() {
+		type FieldOptionsAccount struct {
+			Email string `graphql:"email"`
+		}
+
+		obj := gqlstruct.Struct(FieldOptionsAccount{}, gqlstruct.WithFieldOptions("email", gqlstruct.WithDescription("The email.")))
+		Expect(obj.Fields()["email"].Description).To(Equal("The email."))
+	})
+
+	It("should only be a struct option", func() {
+		var option interface{} = gqlstruct.WithFieldOptions("name")
+		_, ok := option.(gqlstruct.ObjectOption)
+		Expect(ok).To(BeFalse())
+	})
+})
@@ -0,0 +1,841 @@
+package gqlstruct
+
+import (
+	"errors"
+	"fmt"
+	"github.com/graphql-go/graphql"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// FilterOperator is an operator of the conditions of a `Filter`.
+type FilterOperator string
+
+const (
+	// FilterEq matches the values equal to the value of the condition.
+	FilterEq FilterOperator = "eq"
+	// FilterNe matches the values different from the value of the condition.
+	FilterNe FilterOperator = "ne"
+	// FilterIn matches the values in the list of the condition.
+	FilterIn FilterOperator = "in"
+	// FilterGt matches the values greater than the value of the condition.
+	FilterGt FilterOperator = "gt"
+	// FilterLt matches the values less than the value of the condition.
+	FilterLt FilterOperator = "lt"
+	// FilterContains matches the strings that contain the value of the
+	// condition.
+	FilterContains FilterOperator = "contains"
+)
+
+// FilterCondition is a condition of a field of the model.
+type FilterCondition struct {
+	// Field is the GraphQL name of the field.
+	Field    string
+	Operator FilterOperator
+	// Value is the value of the condition, parsed by the type of the field.
+	// The value of `FilterIn` is a `[]interface{}`.
+	Value interface{}
+}
+
+// Filter is the decoded value of a filter argument (check `WithFilter` and
+// `ParseFilter`). A value matches the filter when it matches all of its
+// conditions, all filters of And, at least one filter of Or (if any) and
+// does not match Not.
+//
+// Data stores can translate the filter into their own queries.
+type Filter struct {
+	Conditions []FilterCondition
+	And        []*Filter
+	Or         []*Filter
+	Not        *Filter
+}
+
+// Sort is a field used to sort a list, decoded from a sort argument (check
+// `WithFilter` and `ParseSort`).
+type Sort struct {
+	// Field is the GraphQL name of the field.
+	Field string
+	Desc  bool
+}
+
+// filterTypes are the input types of the filters of a model.
+type filterTypes struct {
+	filter *graphql.InputObject
+	sort   *graphql.Enum
+}
+
+type withFilter struct {
+	encoder *encoder
+	model   interface{}
+}
+
+// WithFilter creates a `FieldOption` that adds the `filter` and `sort`
+// arguments to a list field, described from the tagged fields of the model:
+//
+// ```
+// gqlstruct.WithFieldOptions("users", gqlstruct.WithFilter(User{}))
+// ```
+//
+// The `UserFilter` input object has a condition for each scalar and enum
+// field (`eq`, `ne`, `in`, `gt`, `lt` and `contains`, accordingly to the type
+// of the field) and the `and`, `or` and `not` filters. The `UserSort` enum
+// has the `<FIELD>_ASC` and `<FIELD>_DESC` values.
+//
+// The list resolved by the field is filtered and sorted in memory (check
+// `FilterSlice`). Connections are filtered before being paginated, unless
+// they are resolved by `WithConnectionResolve`, which receives the
+// arguments to decode them with `ParseFilter` and `ParseSort`.
+//
+// The types are built by the encoder building the field or, when the option
+// is applied directly (`ApplyField`), by the default encoder.
+func WithFilter(model interface{}) FieldOption {
+	return &withFilter{
+		model: model,
+	}
+}
+
+// WithFilter creates a `FieldOption` that adds the `filter` and `sort`
+// arguments to a list field (check `WithFilter`), with the types built by the
+// encoder.
+func (enc *encoder) WithFilter(model interface{}) FieldOption {
+	return &withFilter{
+		encoder: enc,
+		model:   model,
+	}
+}
+
+// ApplyField adds the filter to the field, with the types built by the
+// encoder of the option.
+func (option *withFilter) ApplyField(field *graphql.Field) error {
+	enc := option.encoder
+	if enc == nil {
+		enc = defaultEncoder
+	}
+	defer enc.lock()()
+	return enc.endBuild(enc.filterField(field, reflect.TypeOf(option.model)))
+}
+
+// filterField adds the filter of the model to the list field.
+func (enc *encoder) filterField(field *graphql.Field, model reflect.Type) error {
+	t := field.Type
+	if n, ok := t.(*graphql.NonNull); ok {
+		t = n.OfType
+	}
+
+	var items graphql.Type
+	conn, isConnection := t.(*graphql.Object)
+	if isConnection {
+		items, isConnection = enc.connectionNode(conn)
+	}
+	if list, ok := t.(*graphql.List); ok {
+		items = list.OfType
+	} else if !isConnection {
+		return fmt.Errorf("only lists can be filtered, not %s", field.Type)
+	}
+
+	types, err := enc.filterOf(model)
+	if err != nil {
+		return err
+	}
+	if n, ok := items.(*graphql.NonNull); ok {
+		items = n.OfType
+	}
+	if model.Kind() == reflect.Ptr {
+		model = model.Elem()
+	}
+	if named, ok := enc.names[items.Name()]; ok && named.gt == items && named.t != model {
+		// The items are filtered by the fields of the model, so they must be
+		// of the same type.
+		return fmt.Errorf("the items of %s cannot be filtered by %s", field.Type, model.Name())
+	}
+
+	if field.Args == nil {
+		field.Args = graphql.FieldConfigArgument{}
+	}
+	args := graphql.FieldConfigArgument{
+		"filter": &graphql.ArgumentConfig{
+			Type: types.filter,
+		},
+		"sort": &graphql.ArgumentConfig{
+			Type: graphql.NewList(graphql.NewNonNull(types.sort)),
+		},
+	}
+	for name, arg := range args {
+		if _, ok := field.Args[name]; ok {
+			return fmt.Errorf("the argument '%s' is already defined", name)
+		}
+		field.Args[name] = arg
+	}
+
+	if !isConnection {
+		field.Resolve = enc.config.filterResolve(field.Resolve)
+		return nil
+	}
+
+	// The list of the connection is filtered before being paginated.
+	list, ok := enc.connectionLists[field]
+	if !ok {
+		return errors.New("connections must be filtered when they are built")
+	}
+	if list != nil {
+		list = enc.config.filterResolve(list)
+		enc.connectionLists[field] = list
+		field.Resolve = connectionResolve(list)
+	}
+	return nil
+}
+
+// filterOf builds the filter and the sort types of the model.
+func (enc *encoder) filterOf(t reflect.Type) (*filterTypes, error) {
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("cannot build a filter from a non struct")
+	}
+	if r, ok := enc.filters[t]; ok {
+		return r, nil
+	}
+
+	name := enc.config.typeName(t.Name())
+	defer enc.rootPath(name + "Filter")()
+
+	fields := graphql.InputObjectConfigFieldMap{}
+	sortValues := graphql.EnumValueConfigMap{}
+	if err := enc.buildFilterFields(t, fields, sortValues); err != nil {
+		return nil, err
+	}
+	if len(fields) == 0 {
+		return nil, fmt.Errorf("%s: no fields can be filtered", t.Name())
+	}
+
+	filter := graphql.NewInputObject(graphql.InputObjectConfig{
+		Name:   name + "Filter",
+		Fields: fields,
+	})
+	if err := enc.registerName(t, filter); err != nil {
+		return nil, err
+	}
+	filter.AddFieldConfig("and", &graphql.InputObjectFieldConfig{
+		Type:        graphql.NewList(graphql.NewNonNull(filter)),
+		Description: "Matches when all filters match.",
+	})
+	filter.AddFieldConfig("or", &graphql.InputObjectFieldConfig{
+		Type:        graphql.NewList(graphql.NewNonNull(filter)),
+		Description: "Matches when any filter matches.",
+	})
+	filter.AddFieldConfig("not", &graphql.InputObjectFieldConfig{
+		Type:        filter,
+		Description: "Matches when the filter does not match.",
+	})
+
+	sortEnum := graphql.NewEnum(graphql.EnumConfig{
+		Name:   name + "Sort",
+		Values: sortValues,
+	})
+	if err := enc.registerName(t, sortEnum); err != nil {
+		return nil, err
+	}
+
+	r := &filterTypes{
+		filter: filter,
+		sort:   sortEnum,
+	}
+	enc.filters[t] = r
+	enc.onRollback(func() {
+		delete(enc.filters, t)
+	})
+	return r, nil
+}
+
+// buildFilterFields adds the conditions and the sort values of the scalar
+// and enum fields of the struct. The fields of the embedded interfaces are
+// promoted.
+func (enc *encoder) buildFilterFields(t reflect.Type, fields graphql.InputObjectConfigFieldMap, sortValues graphql.EnumValueConfigMap) error {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag, ok := enc.config.fieldTag(field)
+		if !ok {
+			continue
+		}
+
+		info, err := ParseTag(tag)
+		if err != nil {
+			if err := enc.fieldError(field.Name, field.Type, newErrInvalidTag(err, t, field, tag)); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if info.Interface {
+			embeddedType := field.Type
+			if embeddedType.Kind() == reflect.Ptr {
+				embeddedType = embeddedType.Elem()
+			}
+			if err := enc.buildFilterFields(embeddedType, fields, sortValues); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if _, ok := fields[info.Name]; ok || !filterable(field.Type) {
+			// The first field with the name is kept, and only scalars and
+			// enums can be filtered.
+			continue
+		}
+
+		if info.Name == "and" || info.Name == "or" || info.Name == "not" {
+			if err := enc.fieldError(info.Name, field.Type, fmt.Errorf("%s.%s: '%s' is reserved by the filter", t.Name(), field.Name, info.Name)); err != nil {
+				return err
+			}
+			continue
+		}
+
+		fieldType, err := enc.buildInputFieldType(field.Type)
+		if err != nil {
+			if err := enc.fieldError(info.Name, field.Type, NewErrTypeNotRecognizedWithStruct(err, t, field)); err != nil {
+				return err
+			}
+			continue
+		}
+
+		conditions, err := enc.conditionsOf(fieldType)
+		if err != nil {
+			if err := enc.fieldError(info.Name, field.Type, err); err != nil {
+				return err
+			}
+			continue
+		}
+		fields[info.Name] = &graphql.InputObjectFieldConfig{
+			Type:        conditions,
+			Description: info.Description,
+		}
+
+		value := upperSnakeCase(info.Name)
+		sortValues[value+"_ASC"] = &graphql.EnumValueConfig{
+			Value: Sort{Field: info.Name},
+		}
+		sortValues[value+"_DESC"] = &graphql.EnumValueConfig{
+			Value: Sort{Field: info.Name, Desc: true},
+		}
+	}
+	return nil
+}
+
+// filterable checks if the type can be filtered: scalars, enums and custom
+// types, but not objects and lists.
+func filterable(t reflect.Type) bool {
+	if _, ok := typedOf(t); ok {
+		return true
+	}
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t == timeType {
+		return true
+	}
+	switch t.Kind() {
+	case reflect.Struct, reflect.Array, reflect.Slice, reflect.Map, reflect.Interface, reflect.Func, reflect.Chan:
+		return false
+	}
+	return true
+}
+
+// conditionsOf builds the input object with the conditions of the type
+// (`StringFilter`, `IntFilter`, ...), shared by all filters of the encoder.
+func (enc *encoder) conditionsOf(t graphql.Input) (*graphql.InputObject, error) {
+	if r, ok := enc.conditions[t]; ok {
+		return r, nil
+	}
+
+	var operators []FilterOperator
+	switch t {
+	case graphql.String:
+		operators = []FilterOperator{FilterEq, FilterNe, FilterIn, FilterGt, FilterLt, FilterContains}
+	case graphql.Int, graphql.Float, graphql.DateTime:
+		operators = []FilterOperator{FilterEq, FilterNe, FilterIn, FilterGt, FilterLt}
+	case graphql.Boolean:
+		operators = []FilterOperator{FilterEq, FilterNe}
+	default:
+		switch t.(type) {
+		case *graphql.Scalar, *graphql.Enum:
+			operators = []FilterOperator{FilterEq, FilterNe, FilterIn}
+		default:
+			return nil, fmt.Errorf("%s cannot be filtered", t)
+		}
+	}
+
+	fields := graphql.InputObjectConfigFieldMap{}
+	for _, operator := range operators {
+		var fieldType graphql.Input = t
+		if operator == FilterIn {
+			fieldType = graphql.NewList(graphql.NewNonNull(t))
+		}
+		fields[string(operator)] = &graphql.InputObjectFieldConfig{
+			Type: fieldType,
+		}
+	}
+	name := enc.derivedName(t)
+	r := graphql.NewInputObject(graphql.InputObjectConfig{
+		Name:        name + "Filter",
+		Description: "The conditions of " + t.Name() + " fields.",
+		Fields:      fields,
+	})
+	if err := enc.registerName(reflect.TypeOf(FilterCondition{}), r); err != nil {
+		return nil, err
+	}
+	enc.conditions[t] = r
+	enc.onRollback(func() {
+		delete(enc.conditions, t)
+	})
+	return r, nil
+}
+
+// upperSnakeCase converts the name of a field to the format of the enum
+// values: "createdAt" becomes "CREATED_AT".
+func upperSnakeCase(name string) string {
+	var b strings.Builder
+	runes := []rune(name)
+	for i, r := range runes {
+		if i > 0 && unicode.IsUpper(r) && (unicode.IsLower(runes[i-1]) || (i+1 < len(runes) && unicode.IsLower(runes[i+1]))) {
+			b.WriteByte('_')
+		}
+		b.WriteRune(unicode.ToUpper(r))
+	}
+	return b.String()
+}
+
+// ParseFilter decodes the value of a filter argument (`p.Args["filter"]`).
+// A nil value returns a nil filter, which matches everything.
+func ParseFilter(value interface{}) (*Filter, error) {
+	if value == nil {
+		return nil, nil
+	}
+	m, ok := value.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("the filter must be an object, not %T", value)
+	}
+
+	// The fields are sorted, so the conditions are always in the same order.
+	names := make([]string, 0, len(m))
+	for name := range m {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	r := &Filter{}
+	for _, name := range names {
+		value := m[name]
+		switch name {
+		case "and", "or":
+			list, ok := value.([]interface{})
+			if !ok {
+				return nil, fmt.Errorf("%s: the filters must be a list, not %T", name, value)
+			}
+			for _, item := range list {
+				filter, err := ParseFilter(item)
+				if err != nil {
+					return nil, err
+				}
+				if name == "and" {
+					r.And = append(r.And, filter)
+				} else {
+					r.Or = append(r.Or, filter)
+				}
+			}
+		case "not":
+			filter, err := ParseFilter(value)
+			if err != nil {
+				return nil, err
+			}
+			r.Not = filter
+		default:
+			conditions, ok := value.(map[string]interface{})
+			if !ok {
+				return nil, fmt.Errorf("%s: the conditions must be an object, not %T", name, value)
+			}
+			operators := make([]string, 0, len(conditions))
+			for operator := range conditions {
+				operators = append(operators, operator)
+			}
+			sort.Strings(operators)
+			for _, operator := range operators {
+				r.Conditions = append(r.Conditions, FilterCondition{
+					Field:    name,
+					Operator: FilterOperator(operator),
+					Value:    conditions[operator],
+				})
+			}
+		}
+	}
+	return r, nil
+}
+
+// ParseSort decodes the value of a sort argument (`p.Args["sort"]`).
+func ParseSort(value interface{}) ([]Sort, error) {
+	if value == nil {
+		return nil, nil
+	}
+	list, ok := value.([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("the sort must be a list, not %T", value)
+	}
+	r := make([]Sort, len(list))
+	for i, item := range list {
+		s, ok := item.(Sort)
+		if !ok {
+			return nil, fmt.Errorf("the sort must be a list of Sort, not %T", item)
+		}
+		r[i] = s
+	}
+	return r, nil
+}
+
+// filterResolve creates a resolver that filters and sorts the list resolved
+// by resolve, accordingly to the filter and sort arguments.
+func (cfg *encoderConfig) filterResolve(resolve graphql.FieldResolveFn) graphql.FieldResolveFn {
+	if resolve == nil {
+		resolve = graphql.DefaultResolveFn
+	}
+	return func(p graphql.ResolveParams) (interface{}, error) {
+		value, err := resolve(p)
+		if err != nil || value == nil {
+			return nil, err
+		}
+		filter, err := ParseFilter(p.Args["filter"])
+		if err != nil {
+			return nil, err
+		}
+		sorts, err := ParseSort(p.Args["sort"])
+		if err != nil {
+			return nil, err
+		}
+		return cfg.filterSlice(value, filter, sorts)
+	}
+}
+
+// FilterSlice returns a new slice with the items of the slice (or array)
+// that match the filter, sorted accordingly to the sorts. The fields are
+// matched by the names in their "graphql" tags.
+func FilterSlice(slice interface{}, filter *Filter, sorts []Sort) (interface{}, error) {
+	return defaultEncoder.FilterSlice(slice, filter, sorts)
+}
+
+// FilterSlice returns a new slice with the items of the slice that match the
+// filter, sorted accordingly to the sorts. The fields are matched the same
+// way the encoder describes them (check `NewEncoder`).
+func (enc *encoder) FilterSlice(slice interface{}, filter *Filter, sorts []Sort) (interface{}, error) {
+	return enc.config.filterSlice(slice, filter, sorts)
+}
+
+// filterSlice implements `FilterSlice`.
+func (cfg *encoderConfig) filterSlice(slice interface{}, filter *Filter, sorts []Sort) (interface{}, error) {
+	v := reflect.ValueOf(slice)
+	if v.Kind() == reflect.Ptr {
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Slice && v.Kind() != reflect.Array {
+		return nil, fmt.Errorf("cannot filter %T, it is not a slice", slice)
+	}
+
+	elemType := v.Type().Elem()
+	structType := elemType
+	if structType.Kind() == reflect.Ptr {
+		structType = structType.Elem()
+	}
+	if structType.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("cannot filter %T, its items are not structs", slice)
+	}
+	indexes := cfg.fieldIndexes(structType)
+
+	r := reflect.MakeSlice(reflect.SliceOf(elemType), 0, v.Len())
+	for i := 0; i < v.Len(); i++ {
+		ok, err := matchFilter(v.Index(i), filter, indexes)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			r = reflect.Append(r, v.Index(i))
+		}
+	}
+
+	if len(sorts) == 0 {
+		return r.Interface(), nil
+	}
+	for _, s := range sorts {
+		if _, ok := indexes[s.Field]; !ok {
+			return nil, fmt.Errorf("unknown field '%s'", s.Field)
+		}
+	}
+
+	var sortErr error
+	sort.SliceStable(r.Interface(), func(i, j int) bool {
+		for _, s := range sorts {
+			a, _ := fieldByIndex(r.Index(i), indexes[s.Field])
+			b, _ := fieldByIndex(r.Index(j), indexes[s.Field])
+			c, err := compareValues(a, b)
+			if err != nil {
+				sortErr = err
+				return false
+			}
+			if c != 0 {
+				return (c < 0) != s.Desc
+			}
+		}
+		return false
+	})
+	if sortErr != nil {
+		return nil, sortErr
+	}
+	return r.Interface(), nil
+}
+
+// fieldIndexes maps the GraphQL names of the fields of the struct to their
+// indexes, including the fields promoted from the embedded interfaces.
+func (cfg *encoderConfig) fieldIndexes(t reflect.Type) map[string][]int {
+	r := make(map[string][]int)
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag, ok := cfg.fieldTag(field)
+		if !ok {
+			continue
+		}
+		info, err := ParseTag(tag)
+		if err != nil {
+			continue
+		}
+		if info.Interface {
+			embeddedType := field.Type
+			if embeddedType.Kind() == reflect.Ptr {
+				embeddedType = embeddedType.Elem()
+			}
+			for name, index := range cfg.fieldIndexes(embeddedType) {
+				if _, ok := r[name]; !ok {
+					r[name] = append([]int{i}, index...)
+				}
+			}
+			continue
+		}
+		r[info.Name] = []int{i}
+	}
+	return r
+}
+
+// fieldByIndex returns the value of the nested field, dereferencing the
+// pointers. Nil pointers return an invalid value.
+func fieldByIndex(v reflect.Value, index []int) (reflect.Value, bool) {
+	for _, i := range index {
+		for v.Kind() == reflect.Ptr {
+			if v.IsNil() {
+				return reflect.Value{}, false
+			}
+			v = v.Elem()
+		}
+		v = v.Field(i)
+	}
+	for v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return reflect.Value{}, false
+		}
+		v = v.Elem()
+	}
+	return v, true
+}
+
+// matchFilter checks if the value matches the filter.
+func matchFilter(v reflect.Value, filter *Filter, indexes map[string][]int) (bool, error) {
+	if filter == nil {
+		return true, nil
+	}
+
+	for _, condition := range filter.Conditions {
+		index, ok := indexes[condition.Field]
+		if !ok {
+			return false, fmt.Errorf("unknown field '%s'", condition.Field)
+		}
+		field, _ := fieldByIndex(v, index)
+		ok, err := matchCondition(field, condition)
+		if err != nil || !ok {
+			return false, err
+		}
+	}
+
+	for _, and := range filter.And {
+		ok, err := matchFilter(v, and, indexes)
+		if err != nil || !ok {
+			return false, err
+		}
+	}
+
+	if len(filter.Or) > 0 {
+		matched := false
+		for _, or := range filter.Or {
+			ok, err := matchFilter(v, or, indexes)
+			if err != nil {
+				return false, err
+			}
+			if ok {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return false, nil
+		}
+	}
+
+	if filter.Not != nil {
+		ok, err := matchFilter(v, filter.Not, indexes)
+		if err != nil || ok {
+			return false, err
+		}
+	}
+	return true, nil
+}
+
+// matchCondition checks if the value of the field matches the condition.
+// Null values only match the `FilterNe` conditions.
+func matchCondition(field reflect.Value, condition FilterCondition) (bool, error) {
+	if !field.IsValid() || condition.Value == nil {
+		bothNull := !field.IsValid() && condition.Value == nil
+		switch condition.Operator {
+		case FilterEq:
+			return bothNull, nil
+		case FilterNe:
+			return !bothNull, nil
+		}
+		return false, nil
+	}
+
+	switch condition.Operator {
+	case FilterEq, FilterNe, FilterGt, FilterLt:
+		c, err := compareValues(field, reflect.ValueOf(condition.Value))
+		if err != nil {
+			return false, fmt.Errorf("%s: %s", condition.Field, err.Error())
+		}
+		switch condition.Operator {
+		case FilterEq:
+			return c == 0, nil
+		case FilterNe:
+			return c != 0, nil
+		case FilterGt:
+			return c > 0, nil
+		}
+		return c < 0, nil
+	case FilterIn:
+		values, ok := condition.Value.([]interface{})
+		if !ok {
+			return false, fmt.Errorf("%s: the values of 'in' must be a list, not %T", condition.Field, condition.Value)
+		}
+		for _, value := range values {
+			c, err := compareValues(field, reflect.ValueOf(value))
+			if err != nil {
+				return false, fmt.Errorf("%s: %s", condition.Field, err.Error())
+			}
+			if c == 0 {
+				return true, nil
+			}
+		}
+		return false, nil
+	case FilterContains:
+		value, ok := condition.Value.(string)
+		if !ok || field.Kind() != reflect.String {
+			return false, fmt.Errorf("%s: only strings can contain strings", condition.Field)
+		}
+		return strings.Contains(field.String(), value), nil
+	}
+	return false, fmt.Errorf("%s: unknown operator '%s'", condition.Field, condition.Operator)
+}
+
+// compareValues compares the values, returning -1, 0 or 1. Invalid values
+// (null) come first.
+func compareValues(a, b reflect.Value) (int, error) {
+	for a.IsValid() && a.Kind() == reflect.Ptr {
+		a = reflect.Indirect(a)
+	}
+	for b.IsValid() && b.Kind() == reflect.Ptr {
+		b = reflect.Indirect(b)
+	}
+	switch {
+	case !a.IsValid() && !b.IsValid():
+		return 0, nil
+	case !a.IsValid():
+		return -1, nil
+	case !b.IsValid():
+		return 1, nil
+	}
+
+	if a.Type() == timeType && b.Type() == timeType {
+		ta, tb := a.Interface().(time.Time), b.Interface().(time.Time)
+		switch {
+		case ta.Before(tb):
+			return -1, nil
+		case ta.After(tb):
+			return 1, nil
+		}
+		return 0, nil
+	}
+
+	switch {
+	case isSignedInt(a) && isSignedInt(b):
+		switch {
+		case a.Int() < b.Int():
+			return -1, nil
+		case a.Int() > b.Int():
+			return 1, nil
+		}
+		return 0, nil
+	case isNumber(a) && isNumber(b):
+		return compareFloats(toFloat(a), toFloat(b)), nil
+	case a.Kind() == reflect.String && b.Kind() == reflect.String:
+		return strings.Compare(a.String(), b.String()), nil
+	case a.Kind() == reflect.Bool && b.Kind() == reflect.Bool:
+		switch {
+		case a.Bool() == b.Bool():
+			return 0, nil
+		case b.Bool():
+			return -1, nil
+		}
+		return 1, nil
+	}
+	return 0, fmt.Errorf("cannot compare %s with %s", a.Type(), b.Type())
+}
+
+func isSignedInt(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return true
+	}
+	return false
+}
+
+func isNumber(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Float32, reflect.Float64:
+		return true
+	}
+	return isSignedInt(v)
+}
+
+func toFloat(v reflect.Value) float64 {
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(v.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(v.Uint())
+	}
+	return v.Float()
+}
+
+func compareFloats(a, b float64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
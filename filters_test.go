@@ -0,0 +1,302 @@
+package gqlstruct_test
+
+import (
+	"github.com/graphql-go/graphql"
+	"github.com/lab259/go-graphql-struct"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"time"
+)
+
+type FilterAddress struct {
+	City string `graphql:"city"`
+}
+
+type FilterUser struct {
+	Node      `graphql:",interface"`
+	Name      string        `graphql:"name"`
+	Age       int           `graphql:"age"`
+	Score     *float64      `graphql:"score"`
+	Active    bool          `graphql:"active"`
+	Status    Status        `graphql:"status"`
+	CreatedAt time.Time     `graphql:"createdAt"`
+	Address   FilterAddress `graphql:"address"`
+	Tags      []string      `graphql:"tags"`
+}
+
+type FilterQuery struct {
+	Users []*FilterUser `graphql:"users"`
+	Pages []*FilterUser `graphql:"pages,connection"`
+}
+
+func float64Ptr(f float64) *float64 {
+	return &f
+}
+
+var filterUsers = []*FilterUser{
+	{Node: Node{ID: "1"}, Name: "Alice", Age: 30, Score: float64Ptr(7.5), Active: true, Status: StatusActive, CreatedAt: time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)},
+	{Node: Node{ID: "2"}, Name: "Bob", Age: 25, Active: false, Status: StatusBlocked, CreatedAt: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)},
+	{Node: Node{ID: "3"}, Name: "Carol", Age: 35, Score: float64Ptr(9), Active: true, Status: StatusActive, CreatedAt: time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC)},
+	{Node: Node{ID: "4"}, Name: "Dave", Age: 25, Score: float64Ptr(6), Active: true, Status: StatusRemoved, CreatedAt: time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)},
+}
+
+var _ = Describe("Filters", func() {
+	newSchema := func() graphql.Schema {
+		enc := gqlstruct.NewEncoder()
+		_, err := enc.Struct(FilterQuery{},
+			gqlstruct.WithFieldOptions("users", gqlstruct.WithFilter(FilterUser{})),
+			gqlstruct.WithFieldOptions("pages", gqlstruct.WithFilter(FilterUser{})),
+		)
+		Expect(err).ToNot(HaveOccurred())
+
+		schema, err := enc.Schema(&FilterQuery{Users: filterUsers, Pages: filterUsers}, nil, nil)
+		Expect(err).ToNot(HaveOccurred())
+		return schema
+	}
+
+	ids := func(r *graphql.Result, field string) []interface{} {
+		Expect(r.Errors).To(BeEmpty())
+		items := r.Data.(map[string]interface{})[field].([]interface{})
+		ids := make([]interface{}, len(items))
+		for i, item := range items {
+			ids[i] = item.(map[string]interface{})["id"]
+		}
+		return ids
+	}
+
+	It("should build the filter and the sort types of the model", func() {
+		enc := gqlstruct.NewEncoder()
+		obj, err := enc.Struct(FilterQuery{}, gqlstruct.WithFieldOptions("users", gqlstruct.WithFilter(FilterUser{})))
+		Expect(err).ToNot(HaveOccurred())
+
+		args := obj.Fields()["users"].Args
+		Expect(args).To(HaveLen(2))
+		Expect(args[0].Type.String()).To(Or(Equal("FilterUserFilter"), Equal("[FilterUserSort!]")))
+
+		sdl := enc.PrintSDL()
+		Expect(sdl).To(ContainSubstring(`input FilterUserFilter {
+  active: BooleanFilter
+  age: IntFilter
+  """Matches when all filters match."""
+  and: [FilterUserFilter!]
+  createdAt: DateTimeFilter
+  id: StringFilter
+  name: StringFilter
+  """Matches when the filter does not match."""
+  not: FilterUserFilter
+  """Matches when any filter matches."""
+  or: [FilterUserFilter!]
+  score: FloatFilter
+  status: StatusFilter
+}`))
+		Expect(sdl).To(ContainSubstring(`input StringFilter {
+  contains: String
+  eq: String
+  gt: String
+  in: [String!]
+  lt: String
+  ne: String
+}`))
+		Expect(sdl).To(ContainSubstring(`input StatusFilter {
+  eq: Status
+  in: [Status!]
+  ne: Status
+}`))
+		Expect(sdl).To(ContainSubstring(`input BooleanFilter {
+  eq: Boolean
+  ne: Boolean
+}`))
+		Expect(sdl).To(ContainSubstring("CREATED_AT_ASC"))
+		Expect(sdl).To(ContainSubstring("CREATED_AT_DESC"))
+	})
+
+	It("should filter the lists", func() {
+		schema := newSchema()
+		queries := map[string][]interface{}{
+			`{ users(filter: { name: { eq: "Bob" } }) { id } }`:                                         {"2"},
+			`{ users(filter: { name: { contains: "a" } }) { id } }`:                                     {"3", "4"},
+			`{ users(filter: { age: { gt: 25, lt: 35 } }) { id } }`:                                     {"1"},
+			`{ users(filter: { status: { in: [ACTIVE, REMOVED] } }) { id } }`:                           {"1", "3", "4"},
+			`{ users(filter: { active: { ne: true } }) { id } }`:                                        {"2"},
+			`{ users(filter: { score: { gt: 6.5 } }) { id } }`:                                          {"1", "3"},
+			`{ users(filter: { createdAt: { lt: "2020-06-01T00:00:00Z" } }) { id } }`:                   {"1", "3"},
+			`{ users(filter: { id: { ne: "1" }, not: { age: { eq: 25 } } }) { id } }`:                   {"3"},
+			`{ users(filter: { or: [{ name: { eq: "Alice" } }, { age: { eq: 35 } }] }) { id } }`:        {"1", "3"},
+			`{ users(filter: { and: [{ active: { eq: true } }, { status: { ne: ACTIVE } }] }) { id } }`: {"4"},
+		}
+		for query, expected := range queries {
+			r := graphql.Do(graphql.Params{
+				Schema:        schema,
+				RequestString: query,
+			})
+			Expect(ids(r, "users")).To(Equal(expected), query)
+		}
+	})
+
+	It("should sort the lists", func() {
+		r := graphql.Do(graphql.Params{
+			Schema:        newSchema(),
+			RequestString: `{ users(sort: [AGE_ASC, NAME_DESC]) { id } }`,
+		})
+		Expect(ids(r, "users")).To(Equal([]interface{}{"4", "2", "1", "3"}))
+
+		r = graphql.Do(graphql.Params{
+			Schema:        newSchema(),
+			RequestString: `{ users(filter: { active: { eq: true } }, sort: [CREATED_AT_DESC]) { id } }`,
+		})
+		Expect(ids(r, "users")).To(Equal([]interface{}{"4", "1", "3"}))
+	})
+
+	It("should filter the connections before paginating them", func() {
+		r := graphql.Do(graphql.Params{
+			Schema:        newSchema(),
+			RequestString: `{ pages(filter: { active: { eq: true } }, sort: [SCORE_DESC], first: 2) { edges { node { id } } pageInfo { hasNextPage } } }`,
+		})
+		Expect(r.Errors).To(BeEmpty())
+		Expect(r.Data).To(Equal(map[string]interface{}{
+			"pages": map[string]interface{}{
+				"edges": []interface{}{
+					map[string]interface{}{"node": map[string]interface{}{"id": "3"}},
+					map[string]interface{}{"node": map[string]interface{}{"id": "1"}},
+				},
+				"pageInfo": map[string]interface{}{"hasNextPage": true},
+			},
+		}))
+	})
+
+	It("should pass the arguments to the connections resolved by the data source", func() {
+		var filter *gqlstruct.Filter
+		var sorts []gqlstruct.Sort
+
+		enc := gqlstruct.NewEncoder()
+		_, err := enc.Struct(FilterQuery{}, gqlstruct.WithFieldOptions("pages",
+			gqlstruct.WithConnectionResolve(func(p graphql.ResolveParams, args gqlstruct.ConnectionArgs) (*gqlstruct.Connection, error) {
+				var err error
+				filter, err = gqlstruct.ParseFilter(p.Args["filter"])
+				if err != nil {
+					return nil, err
+				}
+				sorts, err = gqlstruct.ParseSort(p.Args["sort"])
+				return &gqlstruct.Connection{}, err
+			}),
+			gqlstruct.WithFilter(FilterUser{}),
+		))
+		Expect(err).ToNot(HaveOccurred())
+		schema, err := enc.Schema(&FilterQuery{}, nil, nil)
+		Expect(err).ToNot(HaveOccurred())
+
+		r := graphql.Do(graphql.Params{
+			Schema:        schema,
+			RequestString: `{ pages(filter: { name: { eq: "Bob" }, or: [{ age: { gt: 20 } }] }, sort: [NAME_ASC]) { edges { cursor } } }`,
+		})
+		Expect(r.Errors).To(BeEmpty())
+		Expect(filter).To(Equal(&gqlstruct.Filter{
+			Conditions: []gqlstruct.FilterCondition{
+				{Field: "name", Operator: gqlstruct.FilterEq, Value: "Bob"},
+			},
+			Or: []*gqlstruct.Filter{
+				{Conditions: []gqlstruct.FilterCondition{{Field: "age", Operator: gqlstruct.FilterGt, Value: 20}}},
+			},
+		}))
+		Expect(sorts).To(Equal([]gqlstruct.Sort{{Field: "name"}}))
+	})
+
+	It("should fail when the field is not a list", func() {
+		type Account struct {
+			Name string `graphql:"name"`
+		}
+
+		_, err := gqlstruct.NewEncoder().Struct(Account{}, gqlstruct.WithFieldOptions("name", gqlstruct.WithFilter(FilterUser{})))
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(Equal("Account.name: only lists can be filtered, not String"))
+	})
+
+	It("should fail when the model has no fields to filter", func() {
+		type Empty struct {
+			Address FilterAddress `graphql:"address"`
+		}
+
+		_, err := gqlstruct.NewEncoder().Struct(FilterQuery{}, gqlstruct.WithFieldOptions("users", gqlstruct.WithFilter(Empty{})))
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("Empty: no fields can be filtered"))
+	})
+
+	It("should fail when the items are not of the model", func() {
+		type Account struct {
+			Addresses []FilterAddress `graphql:"addresses"`
+		}
+
+		_, err := gqlstruct.NewEncoder().Struct(Account{}, gqlstruct.WithFieldOptions("addresses", gqlstruct.WithFilter(FilterUser{})))
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(Equal("Account.addresses: the items of [FilterAddress] cannot be filtered by FilterUser"))
+
+		_, err = gqlstruct.NewEncoder().Struct(FilterQuery{}, gqlstruct.WithFieldOptions("pages", gqlstruct.WithFilter(FilterAddress{})))
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("the items of FilterUserConnection cannot be filtered by FilterAddress"))
+	})
+
+	It("should name the conditions with the prefix and the suffix of the encoder", func() {
+		enc := gqlstruct.NewEncoder(gqlstruct.WithTypePrefix("Api"), gqlstruct.WithTypeSuffix("V2"))
+		_, err := enc.Struct(FilterQuery{}, gqlstruct.WithFieldOptions("users", gqlstruct.WithFilter(FilterUser{})))
+		Expect(err).ToNot(HaveOccurred())
+
+		sdl := enc.PrintSDL()
+		Expect(sdl).To(ContainSubstring("input ApiFilterUserV2Filter {"))
+		Expect(sdl).To(ContainSubstring("  age: ApiIntV2Filter\n"))
+		Expect(sdl).To(ContainSubstring("  status: ApiStatusV2Filter\n"))
+		Expect(sdl).ToNot(ContainSubstring("input IntFilter"))
+	})
+
+	It("should build the types by the encoder of the option", func() {
+		enc := gqlstruct.NewEncoder(gqlstruct.WithTypePrefix("Api"))
+		obj, err := enc.Struct(FilterQuery{})
+		Expect(err).ToNot(HaveOccurred())
+
+		field := &graphql.Field{Type: obj.Fields()["users"].Type}
+		Expect(enc.WithFilter(FilterUser{}).ApplyField(field)).To(Succeed())
+		Expect(field.Args["filter"].Type.Name()).To(Equal("ApiFilterUserFilter"))
+	})
+
+	Describe("FilterSlice", func() {
+		It("should filter and sort the slices", func() {
+			r, err := gqlstruct.FilterSlice(filterUsers, &gqlstruct.Filter{
+				Conditions: []gqlstruct.FilterCondition{
+					{Field: "score", Operator: gqlstruct.FilterNe, Value: nil},
+				},
+			}, []gqlstruct.Sort{{Field: "score"}})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(r).To(Equal([]*FilterUser{filterUsers[3], filterUsers[0], filterUsers[2]}))
+		})
+
+		It("should keep the order of the items with equal values", func() {
+			r, err := gqlstruct.FilterSlice([]FilterUser{*filterUsers[3], *filterUsers[1]}, nil, []gqlstruct.Sort{{Field: "age", Desc: true}})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(r.([]FilterUser)[0].Name).To(Equal("Dave"))
+		})
+
+		It("should fail with unknown fields", func() {
+			_, err := gqlstruct.FilterSlice(filterUsers, &gqlstruct.Filter{
+				Conditions: []gqlstruct.FilterCondition{{Field: "unknown", Operator: gqlstruct.FilterEq, Value: 1}},
+			}, nil)
+			Expect(err).To(MatchError("unknown field 'unknown'"))
+
+			_, err = gqlstruct.FilterSlice(filterUsers, nil, []gqlstruct.Sort{{Field: "unknown"}})
+			Expect(err).To(MatchError("unknown field 'unknown'"))
+		})
+
+		It("should fail comparing values of different types", func() {
+			_, err := gqlstruct.FilterSlice(filterUsers, &gqlstruct.Filter{
+				Conditions: []gqlstruct.FilterCondition{{Field: "name", Operator: gqlstruct.FilterGt, Value: 1}},
+			}, nil)
+			Expect(err).To(MatchError("name: cannot compare string with int"))
+		})
+
+		It("should fail with values that are not slices of structs", func() {
+			_, err := gqlstruct.FilterSlice(1, nil, nil)
+			Expect(err).To(MatchError("cannot filter int, it is not a slice"))
+
+			_, err = gqlstruct.FilterSlice([]int{1}, nil, nil)
+			Expect(err).To(MatchError("cannot filter []int, its items are not structs"))
+		})
+	})
+})
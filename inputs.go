@@ -0,0 +1,210 @@
+package gqlstruct
+
+import (
+	"errors"
+	"fmt"
+	"github.com/graphql-go/graphql"
+	"reflect"
+	"strings"
+)
+
+// inputSuffix is appended to the name of the struct to name its input object
+// counterpart.
+const inputSuffix = "Input"
+
+func (enc *encoder) InputObject(obj interface{}, options ...ObjectOption) (*graphql.InputObject, error) {
+	return enc.InputObjectOf(reflect.TypeOf(obj), options...)
+}
+
+// InputObjectOf returns a `*graphql.InputObject` built from the fields of the
+// struct informed.
+//
+// The fields are extracted the same way `StructOf` does, but their types are
+// built as input types. So, nested structs are generated as input objects
+// too. The name of the input object is the name of the struct followed by
+// "Input" (`Address` becomes `AddressInput`).
+func (enc *encoder) InputObjectOf(t reflect.Type, options ...ObjectOption) (*graphql.InputObject, error) {
+	defer enc.lock()()
+	r, err := enc.inputObjectOf(t, options...)
+	if err := enc.endBuild(err); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// inputObjectOf implements `InputObjectOf`, without locking the encoder.
+func (enc *encoder) inputObjectOf(t reflect.Type, options ...ObjectOption) (*graphql.InputObject, error) {
+	if r, ok := enc.getInputType(t); ok {
+		if d, ok := r.(*graphql.InputObject); ok {
+			return d, nil
+		}
+		return nil, fmt.Errorf("%s is not an graphql.InputObject", r)
+	}
+
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	if t.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("cannot build an input object from a non struct")
+	}
+
+	name := enc.config.typeName(strings.TrimSuffix(t.Name(), inputSuffix)) + inputSuffix
+	defer enc.rootPath(name)()
+
+	objCfg := graphql.InputObjectConfig{
+		Name:   name,
+		Fields: graphql.InputObjectConfigFieldMap{},
+	}
+
+	// Apply options
+	var err error
+	objCfg.Name, objCfg.Description, err = applyTypeOptions(objCfg.Name, options)
+	if err != nil {
+		return nil, err
+	}
+
+	r := graphql.NewInputObject(objCfg)
+	// Registers before building the fields, so recursive structs can find
+	// themselves.
+	if err := enc.registerInputType(t, r); err != nil {
+		return nil, err
+	}
+
+	fields, err := enc.buildInputFields(t)
+	if err != nil {
+		return nil, err
+	}
+	for name, field := range fields {
+		r.AddFieldConfig(name, field)
+	}
+	return r, nil
+}
+
+// buildInputFields builds the input fields of the struct informed. It is used
+// to build input objects and arguments.
+//
+// The fields of the embedded structs tagged as "interface" are promoted, as
+// input types do not have interfaces.
+func (enc *encoder) buildInputFields(t reflect.Type) (graphql.InputObjectConfigFieldMap, error) {
+	fields := graphql.InputObjectConfigFieldMap{}
+
+	// Goes field by field of the object.
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag, ok := enc.config.fieldTag(field)
+		if !ok {
+			// If the field is not described, ignore it.
+			continue
+		}
+
+		info, err := ParseTag(tag)
+		if err != nil {
+			// The name may be invalid, so the path uses the name of the Go field.
+			if err := enc.fieldError(field.Name, field.Type, newErrInvalidTag(err, t, field, tag)); err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		if info.DeprecationReason != "" {
+			if err := enc.fieldError(info.Name, field.Type, newErrInvalidTag(errors.New("arguments and input fields cannot be deprecated"), t, field, tag)); err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		if info.Connection {
+			if err := enc.fieldError(info.Name, field.Type, newErrInvalidTag(errors.New("arguments and input fields cannot be connections"), t, field, tag)); err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		if info.Interface {
+			if !field.Anonymous {
+				if err := enc.fieldError(field.Name, field.Type, newErrInvalidTag(errors.New("only embedded structs can be interfaces"), t, field, tag)); err != nil {
+					return nil, err
+				}
+				continue
+			}
+			embeddedType := field.Type
+			if embeddedType.Kind() == reflect.Ptr {
+				embeddedType = embeddedType.Elem()
+			}
+			embeddedFields, err := enc.buildInputFields(embeddedType)
+			if err != nil {
+				return nil, err
+			}
+			for name, embeddedField := range embeddedFields {
+				if _, ok := fields[name]; !ok {
+					fields[name] = embeddedField
+				}
+			}
+			continue
+		}
+
+		// Nested structs are built as input objects.
+		popPath := enc.pushPath(info.Name)
+		fieldType, err := enc.buildInputFieldType(field.Type)
+		popPath()
+		if err != nil {
+			if err := enc.fieldError(info.Name, field.Type, NewErrTypeNotRecognizedWithStruct(err, t, field)); err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		if info.NonNull {
+			fieldType = graphql.NewNonNull(fieldType)
+		}
+
+		var defaultValue interface{}
+		if info.HasDefault {
+			defaultValue, err = parseDefaultValue(field.Type, fieldType, info.Default)
+			if err != nil {
+				if err := enc.fieldError(info.Name, field.Type, newErrInvalidTag(err, t, field, tag)); err != nil {
+					return nil, err
+				}
+				continue
+			}
+		}
+
+		fields[info.Name] = &graphql.InputObjectFieldConfig{
+			Type:         fieldType,
+			Description:  info.Description,
+			DefaultValue: defaultValue,
+		}
+	}
+	return fields, nil
+}
+
+func (enc *encoder) getInputType(t reflect.Type) (graphql.Input, bool) {
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	gt, ok := enc.inputTypes[t]
+	return gt, ok
+}
+
+func (enc *encoder) registerInputType(t reflect.Type, r graphql.Input) error {
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if err := enc.registerName(t, r); err != nil {
+		return err
+	}
+	enc.inputTypes[t] = r
+	enc.onRollback(func() {
+		delete(enc.inputTypes, t)
+	})
+	return nil
+}
+
+func InputObject(obj interface{}) *graphql.InputObject {
+	r, err := defaultEncoder.InputObject(obj)
+	if err != nil {
+		panic(err.Error())
+	}
+	return r
+}
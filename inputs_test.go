@@ -0,0 +1,122 @@
+package gqlstruct_test
+
+import (
+	"github.com/graphql-go/graphql"
+	"github.com/lab259/go-graphql-struct"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"reflect"
+	"time"
+)
+
+var _ = Describe("InputObjectOf", func() {
+	type Address struct {
+		Street string `graphql:"!street"`
+		Number *int   `graphql:"number"`
+		Notes  string
+	}
+
+	type Profile struct {
+		Name      string     `graphql:"!name"`
+		Tags      []string   `graphql:"tags"`
+		Addresses []*Address `graphql:"addresses"`
+		Address   Address    `graphql:"!address"`
+		BirthDate *time.Time `graphql:"birthDate"`
+	}
+
+	It("should generate an input object from a struct", func() {
+		obj, err := gqlstruct.NewEncoder().InputObjectOf(reflect.TypeOf(Address{}))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(obj.Name()).To(Equal("AddressInput"))
+		fields := obj.Fields()
+		Expect(fields).To(HaveLen(2))
+		Expect(fields).To(HaveKey("street"))
+		Expect(fields["street"].Type.String()).To(Equal("String!"))
+		Expect(fields).To(HaveKey("number"))
+		Expect(fields["number"].Type).To(Equal(graphql.Int))
+	})
+
+	It("should generate nested input objects", func() {
+		obj, err := gqlstruct.NewEncoder().InputObject(&Profile{})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(obj.Name()).To(Equal("ProfileInput"))
+		fields := obj.Fields()
+		Expect(fields).To(HaveLen(5))
+		Expect(fields["tags"].Type.String()).To(Equal("[String]"))
+		Expect(fields["addresses"].Type.String()).To(Equal("[AddressInput]"))
+		Expect(fields["address"].Type.String()).To(Equal("AddressInput!"))
+		Expect(fields["address"].Type.(*graphql.NonNull).OfType).To(BeAssignableToTypeOf(&graphql.InputObject{}))
+		Expect(fields["birthDate"].Type).To(Equal(graphql.DateTime))
+	})
+
+	It("should reuse the input objects already built", func() {
+		enc := gqlstruct.NewEncoder()
+		profile, err := enc.InputObjectOf(reflect.TypeOf(Profile{}))
+		Expect(err).ToNot(HaveOccurred())
+		address, err := enc.InputObjectOf(reflect.TypeOf(&Address{}))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(profile.Fields()["addresses"].Type.(*graphql.List).OfType).To(Equal(address))
+	})
+
+	It("should not suffix a struct already named as an input", func() {
+		type CreateUserInput struct {
+			Name string `graphql:"name"`
+		}
+
+		obj, err := gqlstruct.NewEncoder().InputObject(CreateUserInput{})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(obj.Name()).To(Equal("CreateUserInput"))
+	})
+
+	It("should generate a recursive input object", func() {
+		type Category struct {
+			Name   string    `graphql:"name"`
+			Parent *Category `graphql:"parent"`
+		}
+
+		obj, err := gqlstruct.NewEncoder().InputObject(Category{})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(obj.Fields()["parent"].Type).To(Equal(obj))
+	})
+
+	It("should apply options", func() {
+		obj, err := gqlstruct.NewEncoder().InputObject(Address{}, gqlstruct.WithDescription("Description 1"))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(obj.Description()).To(Equal("Description 1"))
+	})
+
+	It("should fail when an option fails", func() {
+		_, err := gqlstruct.NewEncoder().InputObject(Address{}, &erroredOption{})
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("forced error"))
+	})
+
+	It("should fail when an option sets more than the name and the description", func() {
+		_, err := gqlstruct.NewEncoder().InputObject(Address{}, &isTypeOfOption{})
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(Equal("AddressInput: the option *gqlstruct_test.isTypeOfOption can only be applied to objects"))
+	})
+
+	It("should fail generating an input object from a non struct", func() {
+		_, err := gqlstruct.NewEncoder().InputObjectOf(reflect.TypeOf("data"))
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("cannot build an input object from a non struct"))
+	})
+
+	It("should fail with a not recognized type", func() {
+		type StructExample struct {
+			Field1 []interface{} `graphql:"field1"`
+		}
+
+		_, err := gqlstruct.NewEncoder().InputObject(StructExample{})
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("not recognized"))
+		Expect(err.Error()).To(ContainSubstring("interface {}"))
+	})
+
+	It("should panic using the global encoder with a non struct", func() {
+		Expect(func() {
+			gqlstruct.InputObject("data")
+		}).To(Panic())
+	})
+})
@@ -0,0 +1,98 @@
+package gqlstruct
+
+import (
+	"fmt"
+	"github.com/graphql-go/graphql"
+	"reflect"
+)
+
+// InterfaceOf returns a `*graphql.Interface` built from the fields of the
+// struct informed.
+//
+// Usually, interfaces are not built directly. Instead, the struct is embedded
+// into other structs with the "interface" option:
+//
+// ```
+// type Node struct {
+//     ID string `graphql:"!id"`
+// }
+//
+// type User struct {
+//     Node `graphql:",interface"`
+//     Name string `graphql:"name"`
+// }
+// ```
+//
+// Then, the fields of the interface are promoted to the `User` object, which
+// will implement the `Node` interface.
+//
+// When the struct is also used as the type of fields (as a `node` field that
+// returns any `Node`), the interface must be built before those fields, so
+// they are described as the interface instead of an object.
+func (enc *encoder) InterfaceOf(t reflect.Type, options ...ObjectOption) (*graphql.Interface, error) {
+	defer enc.lock()()
+	r, err := enc.interfaceOf(t, options...)
+	if err := enc.endBuild(err); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// interfaceOf implements `InterfaceOf`, without locking the encoder.
+func (enc *encoder) interfaceOf(t reflect.Type, options ...ObjectOption) (*graphql.Interface, error) {
+	if r, ok := enc.getType(t); ok {
+		if d, ok := r.(*graphql.Interface); ok {
+			return d, nil
+		}
+		return nil, fmt.Errorf("%s is not an graphql.Interface", r)
+	}
+
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	if t.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("cannot build an interface from a non struct")
+	}
+
+	name := enc.config.typeName(t.Name())
+	defer enc.rootPath(name)()
+
+	ifaceCfg := graphql.InterfaceConfig{
+		Name:   name,
+		Fields: graphql.Fields{},
+	}
+
+	// Apply options
+	var err error
+	ifaceCfg.Name, ifaceCfg.Description, err = applyTypeOptions(ifaceCfg.Name, options)
+	if err != nil {
+		return nil, err
+	}
+
+	r := graphql.NewInterface(ifaceCfg)
+	if err := enc.registerType(t, r); err != nil {
+		return nil, err
+	}
+
+	fields, _, err := enc.buildFields(t)
+	if err != nil {
+		return nil, err
+	}
+	for name, field := range fields {
+		r.AddFieldConfig(name, field)
+	}
+	return r, nil
+}
+
+func (enc *encoder) Interface(obj interface{}, options ...ObjectOption) (*graphql.Interface, error) {
+	return enc.InterfaceOf(reflect.TypeOf(obj), options...)
+}
+
+func Interface(obj interface{}) *graphql.Interface {
+	r, err := defaultEncoder.Interface(obj)
+	if err != nil {
+		panic(err.Error())
+	}
+	return r
+}
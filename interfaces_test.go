@@ -0,0 +1,152 @@
+package gqlstruct_test
+
+import (
+	"github.com/graphql-go/graphql"
+	"github.com/lab259/go-graphql-struct"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"reflect"
+	"time"
+)
+
+type Node struct {
+	ID string `graphql:"!id"`
+}
+
+type Timestamped struct {
+	CreatedAt time.Time `graphql:"createdAt"`
+}
+
+type InterfaceUser struct {
+	Node         `graphql:",interface"`
+	*Timestamped `graphql:",interface"`
+	Name         string `graphql:"name"`
+}
+
+type InterfaceGroup struct {
+	Node  `graphql:",interface"`
+	Title string `graphql:"title"`
+}
+
+var _ = Describe("Interface", func() {
+	It("should generate an interface from a struct", func() {
+		iface, err := gqlstruct.NewEncoder().InterfaceOf(reflect.TypeOf(Node{}), gqlstruct.WithDescription("Description 1"))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(iface.Name()).To(Equal("Node"))
+		Expect(iface.Description()).To(Equal("Description 1"))
+		Expect(iface.Fields()).To(HaveLen(1))
+		Expect(iface.Fields()["id"].Type.String()).To(Equal("String!"))
+	})
+
+	It("should implement the interfaces of the embedded structs", func() {
+		enc := gqlstruct.NewEncoder()
+		obj, err := enc.Struct(InterfaceUser{})
+		Expect(err).ToNot(HaveOccurred())
+		fields := obj.Fields()
+		Expect(fields).To(HaveLen(3))
+		Expect(fields).To(HaveKey("id"))
+		Expect(fields).To(HaveKey("createdAt"))
+		Expect(fields).To(HaveKey("name"))
+
+		node, err := enc.Interface(Node{})
+		Expect(err).ToNot(HaveOccurred())
+		timestamped, err := enc.Interface(&Timestamped{})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(obj.Interfaces()).To(ConsistOf(node, timestamped))
+		Expect(obj.IsTypeOf).ToNot(BeNil())
+		Expect(obj.IsTypeOf(graphql.IsTypeOfParams{Value: &InterfaceUser{}})).To(BeTrue())
+		Expect(obj.IsTypeOf(graphql.IsTypeOfParams{Value: InterfaceGroup{}})).To(BeFalse())
+	})
+
+	It("should not generate IsTypeOf for objects without interfaces", func() {
+		type StructExample struct {
+			Field1 string `graphql:"field1"`
+		}
+
+		obj, err := gqlstruct.NewEncoder().Struct(StructExample{})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(obj.Interfaces()).To(BeEmpty())
+		Expect(obj.IsTypeOf).To(BeNil())
+	})
+
+	It("should promote the interface fields to input objects", func() {
+		obj, err := gqlstruct.NewEncoder().InputObject(InterfaceGroup{})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(obj.Fields()).To(HaveLen(2))
+		Expect(obj.Fields()).To(HaveKey("id"))
+		Expect(obj.Fields()).To(HaveKey("title"))
+	})
+
+	It("should fail generating an interface from a non struct", func() {
+		_, err := gqlstruct.NewEncoder().InterfaceOf(reflect.TypeOf("data"))
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("cannot build an interface from a non struct"))
+	})
+
+	It("should fail with an unknown tag option", func() {
+		type StructExample struct {
+			Node `graphql:",unknown"`
+		}
+
+		_, err := gqlstruct.NewEncoder().Struct(StructExample{})
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("unknown option 'unknown'"))
+	})
+
+	It("should resolve abstract queries", func() {
+		enc := gqlstruct.NewEncoder()
+		userType, err := enc.Struct(InterfaceUser{})
+		Expect(err).ToNot(HaveOccurred())
+		groupType, err := enc.Struct(&InterfaceGroup{})
+		Expect(err).ToNot(HaveOccurred())
+		nodeType, err := enc.Interface(Node{})
+		Expect(err).ToNot(HaveOccurred())
+
+		schema, err := graphql.NewSchema(graphql.SchemaConfig{
+			Query: graphql.NewObject(graphql.ObjectConfig{
+				Name: "Query",
+				Fields: graphql.Fields{
+					"nodes": &graphql.Field{
+						Type: graphql.NewList(nodeType),
+						Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+							return []interface{}{
+								&InterfaceUser{Node: Node{ID: "1"}, Name: "John"},
+								InterfaceGroup{Node: Node{ID: "2"}, Title: "Admins"},
+							}, nil
+						},
+					},
+				},
+			}),
+			Types: []graphql.Type{userType, groupType},
+		})
+		Expect(err).ToNot(HaveOccurred())
+
+		r := graphql.Do(graphql.Params{
+			Schema: schema,
+			RequestString: `{
+				nodes {
+					__typename
+					id
+					... on InterfaceUser { name createdAt }
+					... on InterfaceGroup { title }
+				}
+			}`,
+		})
+		Expect(r.Errors).To(BeEmpty())
+		Expect(r.Data).To(Equal(map[string]interface{}{
+			"nodes": []interface{}{
+				map[string]interface{}{
+					"__typename": "InterfaceUser",
+					"id":         "1",
+					"name":       "John",
+					"createdAt":  nil,
+				},
+				map[string]interface{}{
+					"__typename": "InterfaceGroup",
+					"id":         "2",
+					"title":      "Admins",
+				},
+			},
+		}))
+	})
+})
@@ -0,0 +1,260 @@
+package gqlstruct
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/graphql-go/graphql"
+	"reflect"
+	"sort"
+)
+
+// GraphqlMethods is the interface implemented by structs that expose methods
+// as fields.
+type GraphqlMethods interface {
+	// GraphqlMethods returns the methods that will be exposed as fields,
+	// indexed by the name of the method. The values follow the same format of
+	// the "graphql" tag:
+	//
+	// ```
+	// func (u *User) GraphqlMethods() map[string]string {
+	//     return map[string]string{
+	//         "FullName": "!fullName,desc=The first and last names",
+	//         "Orders":   "orders",
+	//     }
+	// }
+	// ```
+	GraphqlMethods() map[string]string
+}
+
+var (
+	graphqlMethodsType = reflect.TypeOf(new(GraphqlMethods)).Elem()
+	contextType        = reflect.TypeOf(new(context.Context)).Elem()
+	errorType          = reflect.TypeOf(new(error)).Elem()
+)
+
+type withMethod struct {
+	method string
+	tag    string
+}
+
+// WithMethod creates a `StructOption` that exposes a method of the struct
+// as a field. The tag follows the same format of the "graphql" tag.
+//
+// The method can receive a `context.Context` and a struct with the arguments
+// of the field (built by `ArgsOf`), in that order. It must return the value
+// of the field and, optionally, an error:
+//
+// ```
+// func (u *User) FullName() string
+// func (u *User) Orders(ctx context.Context, args OrderArgs) ([]Order, error)
+// ```
+func WithMethod(method, tag string) StructOption {
+	return &withMethod{
+		method: method,
+		tag:    tag,
+	}
+}
+
+// ApplyStruct exposes the method as a field of the struct.
+func (option *withMethod) ApplyStruct(cfg *StructConfig) error {
+	if cfg.Methods == nil {
+		cfg.Methods = make(map[string]string)
+	}
+	cfg.Methods[option.method] = option.tag
+	return nil
+}
+
+// structMethods returns the methods that will be exposed as fields of the
+// struct, from the `GraphqlMethods` interface.
+func structMethods(t reflect.Type) map[string]string {
+	methods := make(map[string]string)
+	if reflect.PtrTo(t).Implements(graphqlMethodsType) {
+		for method, tag := range reflect.New(t).Interface().(GraphqlMethods).GraphqlMethods() {
+			methods[method] = tag
+		}
+	}
+	return methods
+}
+
+// buildMethodFields builds the fields of the methods informed, indexed by the
+// name of the method.
+func (enc *encoder) buildMethodFields(t reflect.Type, methods map[string]string) (graphql.Fields, error) {
+	// The methods are sorted, so the types are always built in the same order.
+	names := make([]string, 0, len(methods))
+	for name := range methods {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	fields := graphql.Fields{}
+	// Methods with pointer receivers are included.
+	ptrType := reflect.PtrTo(t)
+	for _, name := range names {
+		tag := methods[name]
+		method, ok := ptrType.MethodByName(name)
+		if !ok {
+			if err := enc.fieldError(name, nil, fmt.Errorf("%s.%s: method not found", t.Name(), name)); err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		// Methods are not Go fields, so the errors of their tags are described
+		// by a field named after them.
+		methodField := reflect.StructField{Name: name, Type: method.Type}
+		info, err := ParseTag(tag)
+		if hasTagOption(tag, "auth") {
+			// Only the Go fields are protected by policies, by their "auth"
+			// tag.
+			err = errors.New("methods do not support policies")
+		}
+		if err != nil {
+			if err := enc.fieldError(name, method.Type, newErrInvalidTag(err, t, methodField, tag)); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		if info.Interface || info.HasDefault {
+			if err := enc.fieldError(info.Name, method.Type, newErrInvalidTag(errors.New("methods do not support the interface and default options"), t, methodField, tag)); err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		popPath := enc.pushPath(info.Name)
+		field, err := enc.buildMethodField(method)
+		popPath()
+		if err != nil {
+			if err := enc.fieldError(info.Name, method.Type, fmt.Errorf("%s.%s: %s", t.Name(), name, err.Error())); err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		if info.Connection {
+			if err := enc.connectionField(field, nil); err != nil {
+				if err := enc.fieldError(info.Name, method.Type, fmt.Errorf("%s.%s: %s", t.Name(), name, err.Error())); err != nil {
+					return nil, err
+				}
+				continue
+			}
+		}
+
+		if info.NonNull {
+			field.Type = graphql.NewNonNull(field.Type)
+		}
+		field.Description = info.Description
+		field.DeprecationReason = info.DeprecationReason
+		fields[info.Name] = field
+	}
+	return fields, nil
+}
+
+// buildMethodField builds the field from the signature of the method.
+func (enc *encoder) buildMethodField(method reflect.Method) (*graphql.Field, error) {
+	mt := method.Type
+	// The first input is the receiver.
+	in := 1
+	withContext := mt.NumIn() > in && mt.In(in) == contextType
+	if withContext {
+		in++
+	}
+
+	var argsType reflect.Type
+	if mt.NumIn() > in {
+		argsType = mt.In(in)
+		in++
+	}
+
+	if mt.NumIn() > in {
+		return nil, errors.New("too many parameters")
+	}
+
+	if mt.NumOut() == 0 || mt.NumOut() > 2 || mt.Out(0) == errorType || (mt.NumOut() == 2 && mt.Out(1) != errorType) {
+		return nil, errors.New("the method must return a value and, optionally, an error")
+	}
+
+	fieldType, err := enc.buildFieldType(mt.Out(0))
+	if err != nil {
+		return nil, err
+	}
+
+	field := &graphql.Field{
+		Type:    fieldType,
+		Resolve: methodResolve(&enc.config, method.Name, withContext, argsType),
+	}
+
+	if argsType != nil {
+		args, err := enc.argsOf(argsType)
+		if err != nil {
+			return nil, err
+		}
+		field.Args = args
+	}
+	return field, nil
+}
+
+// methodResolve creates a resolver that calls the method of the source.
+func methodResolve(cfg *encoderConfig, name string, withContext bool, argsType reflect.Type) graphql.FieldResolveFn {
+	return func(p graphql.ResolveParams) (interface{}, error) {
+		source := reflect.ValueOf(p.Source)
+		if !source.IsValid() || (source.Kind() == reflect.Ptr && source.IsNil()) {
+			return nil, nil
+		}
+		if source.Kind() != reflect.Ptr {
+			// Copies the value, so methods with pointer receivers can be
+			// called.
+			ptr := reflect.New(source.Type())
+			ptr.Elem().Set(source)
+			source = ptr
+		}
+
+		method := source.MethodByName(name)
+		if !method.IsValid() {
+			return nil, fmt.Errorf("%s does not have the method %s", source.Type(), name)
+		}
+
+		in := make([]reflect.Value, 0, 2)
+		if withContext {
+			ctx := p.Context
+			if ctx == nil {
+				ctx = context.Background()
+			}
+			in = append(in, reflect.ValueOf(ctx))
+		}
+		if argsType != nil {
+			args, err := cfg.newArgs(argsType, p.Args)
+			if err != nil {
+				return nil, err
+			}
+			in = append(in, args)
+		}
+
+		return callResult(method.Call(in))
+	}
+}
+
+// newArgs creates a value of the argsType (a struct or a pointer to a
+// struct) with the args decoded.
+func (cfg *encoderConfig) newArgs(argsType reflect.Type, args map[string]interface{}) (reflect.Value, error) {
+	r := reflect.New(argsType).Elem()
+	v := r
+	if argsType.Kind() == reflect.Ptr {
+		r.Set(reflect.New(argsType.Elem()))
+		v = r.Elem()
+	}
+	if err := cfg.decodeArgs(args, v, ""); err != nil {
+		return reflect.Value{}, err
+	}
+	return r, nil
+}
+
+// callResult converts the output of a resolver, called by reflection, into
+// the value and error expected by a `graphql.FieldResolveFn`.
+func callResult(out []reflect.Value) (interface{}, error) {
+	if len(out) == 2 && !out[1].IsNil() {
+		return nil, out[1].Interface().(error)
+	}
+	return out[0].Interface(), nil
+}
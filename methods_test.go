@@ -0,0 +1,187 @@
+package gqlstruct_test
+
+import (
+	"context"
+	"errors"
+	"github.com/graphql-go/graphql"
+	"github.com/lab259/go-graphql-struct"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+type forbiddenKey struct{}
+
+type MethodOrder struct {
+	ID     string `graphql:"id"`
+	Status Status `graphql:"status"`
+}
+
+type MethodOrderArgs struct {
+	Status *Status `graphql:"status"`
+	Limit  int     `graphql:"limit,default=10"`
+}
+
+type MethodUser struct {
+	FirstName string `graphql:"firstName"`
+	LastName  string `graphql:"lastName"`
+	orders    []MethodOrder
+}
+
+func (u *MethodUser) GraphqlMethods() map[string]string {
+	return map[string]string{
+		"FullName": "!fullName,desc=The first and last names",
+		"Orders":   "orders",
+	}
+}
+
+func (u *MethodUser) FullName() string {
+	return u.FirstName + " " + u.LastName
+}
+
+func (u MethodUser) Initials() (string, error) {
+	if u.FirstName == "" || u.LastName == "" {
+		return "", errors.New("incomplete name")
+	}
+	return u.FirstName[:1] + u.LastName[:1], nil
+}
+
+func (u *MethodUser) Orders(ctx context.Context, args MethodOrderArgs) ([]MethodOrder, error) {
+	if ctx.Value(forbiddenKey{}) != nil {
+		return nil, errors.New("forbidden")
+	}
+	r := make([]MethodOrder, 0)
+	for _, order := range u.orders {
+		if args.Status != nil && order.Status != *args.Status {
+			continue
+		}
+		if len(r) == args.Limit {
+			break
+		}
+		r = append(r, order)
+	}
+	return r, nil
+}
+
+func (u *MethodUser) TooManyParams(a, b, c int) string {
+	return ""
+}
+
+func (u *MethodUser) NoReturn() {}
+
+var _ = Describe("Methods", func() {
+	It("should expose the methods as fields", func() {
+		obj, err := gqlstruct.NewEncoder().Struct(MethodUser{}, gqlstruct.WithMethod("Initials", "initials"))
+		Expect(err).ToNot(HaveOccurred())
+		fields := obj.Fields()
+		Expect(fields).To(HaveLen(5))
+		Expect(fields["fullName"].Type.String()).To(Equal("String!"))
+		Expect(fields["fullName"].Description).To(Equal("The first and last names"))
+		Expect(fields["fullName"].Args).To(BeEmpty())
+		Expect(fields["initials"].Type.String()).To(Equal("String"))
+		Expect(fields["orders"].Type.String()).To(Equal("[MethodOrder]"))
+		Expect(fields["orders"].Args).To(HaveLen(2))
+		Expect(fields["orders"].Args[0].Name()).To(Or(Equal("status"), Equal("limit")))
+	})
+
+	It("should resolve the methods", func() {
+		userType, err := gqlstruct.NewEncoder().Struct(&MethodUser{}, gqlstruct.WithMethod("Initials", "initials"))
+		Expect(err).ToNot(HaveOccurred())
+
+		schema, err := graphql.NewSchema(graphql.SchemaConfig{
+			Query: graphql.NewObject(graphql.ObjectConfig{
+				Name: "Query",
+				Fields: graphql.Fields{
+					"users": &graphql.Field{
+						Type: graphql.NewList(userType),
+						Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+							return []interface{}{
+								MethodUser{
+									FirstName: "John",
+									LastName:  "Doe",
+									orders: []MethodOrder{
+										{ID: "1", Status: StatusActive},
+										{ID: "2", Status: StatusBlocked},
+										{ID: "3", Status: StatusActive},
+									},
+								},
+								&MethodUser{FirstName: "Jane"},
+							}, nil
+						},
+					},
+				},
+			}),
+		})
+		Expect(err).ToNot(HaveOccurred())
+
+		r := graphql.Do(graphql.Params{
+			Schema:        schema,
+			RequestString: `{ users { fullName initials orders(status: ACTIVE, limit: 1) { id status } } }`,
+		})
+		Expect(r.Errors).To(HaveLen(1))
+		Expect(r.Errors[0].Message).To(Equal("incomplete name"))
+		Expect(r.Data).To(Equal(map[string]interface{}{
+			"users": []interface{}{
+				map[string]interface{}{
+					"fullName": "John Doe",
+					"initials": "JD",
+					"orders": []interface{}{
+						map[string]interface{}{"id": "1", "status": "ACTIVE"},
+					},
+				},
+				map[string]interface{}{
+					"fullName": "Jane ",
+					"initials": nil,
+					"orders":   []interface{}{},
+				},
+			},
+		}))
+
+		r = graphql.Do(graphql.Params{
+			Schema:        schema,
+			RequestString: `{ users { orders { id } } }`,
+			Context:       context.WithValue(context.Background(), forbiddenKey{}, true),
+		})
+		Expect(r.Errors).To(HaveLen(2))
+		Expect(r.Errors[0].Message).To(Equal("forbidden"))
+	})
+
+	It("should fail when the method does not exist", func() {
+		_, err := gqlstruct.NewEncoder().Struct(MethodUser{}, gqlstruct.WithMethod("Unknown", "unknown"))
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("MethodUser.Unknown: method not found"))
+	})
+
+	It("should fail when the method has too many parameters", func() {
+		_, err := gqlstruct.NewEncoder().Struct(MethodUser{}, gqlstruct.WithMethod("TooManyParams", "tooManyParams"))
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("MethodUser.TooManyParams: too many parameters"))
+	})
+
+	It("should fail when the method does not return a value", func() {
+		_, err := gqlstruct.NewEncoder().Struct(MethodUser{}, gqlstruct.WithMethod("NoReturn", "noReturn"))
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("must return a value"))
+	})
+
+	It("should fail with an invalid tag", func() {
+		_, err := gqlstruct.NewEncoder().Struct(MethodUser{}, gqlstruct.WithMethod("Initials", "initials,default=AB"))
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("MethodUser.Initials: invalid tag"))
+
+		_, err = gqlstruct.NewEncoder().Struct(MethodUser{}, gqlstruct.WithMethod("Initials", "initials,unknown"))
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("unknown option"))
+	})
+
+	It("should fail when the field is already defined", func() {
+		_, err := gqlstruct.NewEncoder().Struct(MethodUser{}, gqlstruct.WithMethod("Initials", "firstName"))
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("the field 'firstName' is already defined"))
+	})
+
+	It("should only be a struct option", func() {
+		var option interface{} = gqlstruct.WithMethod("Initials", "initials")
+		_, ok := option.(gqlstruct.ObjectOption)
+		Expect(ok).To(BeFalse())
+	})
+})
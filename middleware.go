@@ -0,0 +1,102 @@
+package gqlstruct
+
+import (
+	"github.com/graphql-go/graphql"
+	"reflect"
+)
+
+// FieldInfo describes the Go field (or method) a GraphQL field of an object
+// was built from.
+type FieldInfo struct {
+	// Struct is the Go struct the object was built from.
+	Struct reflect.Type
+	// Field is the Go field, whose Index is relative to Struct, so it also
+	// reaches the fields promoted from the embedded interfaces (check
+	// `reflect.Value.FieldByIndex`). It is empty for methods.
+	Field reflect.StructField
+	// Method is the method exposed by the field (check `WithMethod`), nil
+	// for Go fields.
+	Method *reflect.Method
+	// Tag is the information of the tag of the field.
+	Tag Tag
+}
+
+// Middleware wraps the resolver of a field built by the encoder. next is the
+// resolver of the field: the resolver set by `WithResolve`, by a
+// `GraphqlResolver` or by the encoder, or `graphql.DefaultResolveFn`.
+type Middleware func(next graphql.FieldResolveFn, info FieldInfo) graphql.FieldResolveFn
+
+// Use adds middlewares to the default encoder (check the encoder `Use`).
+func Use(middlewares ...Middleware) {
+	defaultEncoder.Use(middlewares...)
+}
+
+// Use adds middlewares that wrap the resolvers of all fields of the objects
+// built by the encoder (Go fields, promoted fields and methods), after their
+// options are applied. So, cross-cutting logic (as authorization, logging or
+// recovering from panics) is plugged in once.
+//
+// The middlewares run in the order they were added, the first one being
+// the outermost. They only wrap the fields of the objects built after they
+// are added.
+func (enc *encoder) Use(middlewares ...Middleware) {
+	defer enc.lock()()
+	enc.middlewares = append(enc.middlewares, middlewares...)
+}
+
+// applyMiddlewares wraps the resolvers of the fields with the middlewares of
+// the encoder, informing the Go fields they were built from.
+func (enc *encoder) applyMiddlewares(fields graphql.Fields, infos map[string]FieldInfo) {
+	if len(enc.middlewares) == 0 {
+		return
+	}
+	for name, field := range fields {
+		info, ok := infos[name]
+		if !ok {
+			continue
+		}
+		resolve := field.Resolve
+		if resolve == nil {
+			resolve = graphql.DefaultResolveFn
+		}
+		for i := len(enc.middlewares) - 1; i >= 0; i-- {
+			resolve = enc.middlewares[i](resolve, info)
+		}
+		field.Resolve = resolve
+	}
+}
+
+// fieldInfos describes the Go fields and methods of the struct, indexed by
+// the names of the GraphQL fields built from them.
+func (enc *encoder) fieldInfos(t reflect.Type, methods map[string]string) map[string]FieldInfo {
+	r := make(map[string]FieldInfo)
+	for name, index := range enc.config.fieldIndexes(t) {
+		field := t.FieldByIndex(index)
+		field.Index = index
+		tag, _ := enc.config.fieldTag(field)
+		info, _ := ParseTag(tag)
+		r[name] = FieldInfo{
+			Struct: t,
+			Field:  field,
+			Tag:    info,
+		}
+	}
+
+	ptrType := reflect.PtrTo(t)
+	for name, tag := range methods {
+		method, ok := ptrType.MethodByName(name)
+		if !ok {
+			continue
+		}
+		info, err := ParseTag(tag)
+		if err != nil {
+			continue
+		}
+		r[info.Name] = FieldInfo{
+			Struct: t,
+			Method: &method,
+			Tag:    info,
+		}
+	}
+	return r
+}
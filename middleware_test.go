@@ -0,0 +1,147 @@
+package gqlstruct_test
+
+import (
+	"fmt"
+	"github.com/graphql-go/graphql"
+	"github.com/lab259/go-graphql-struct"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"reflect"
+)
+
+type MwUser struct {
+	Node     `graphql:",interface"`
+	Name     string                      `graphql:"name"`
+	Nickname string                      `graphql:"nickname"`
+	Custom   CustomFieldTypeWithResolver `graphql:"custom"`
+}
+
+func (u *MwUser) GraphqlMethods() map[string]string {
+	return map[string]string{
+		"Greeting": "greeting",
+	}
+}
+
+func (u *MwUser) Greeting() string {
+	return "Hello " + u.Name
+}
+
+type MwQuery struct {
+	User *MwUser `graphql:"user"`
+}
+
+var _ = Describe("Middlewares", func() {
+	newSchema := func(middlewares ...gqlstruct.Middleware) graphql.Schema {
+		enc := gqlstruct.NewEncoder()
+		enc.Use(middlewares...)
+		_, err := enc.Struct(MwUser{}, gqlstruct.WithFieldOptions("nickname", gqlstruct.WithResolve(func(p graphql.ResolveParams) (interface{}, error) {
+			return "jd", nil
+		})))
+		Expect(err).ToNot(HaveOccurred())
+		schema, err := enc.Schema(&MwQuery{User: &MwUser{Node: Node{ID: "1"}, Name: "John"}}, nil, nil)
+		Expect(err).ToNot(HaveOccurred())
+		return schema
+	}
+
+	It("should wrap the resolvers of all fields", func() {
+		infos := make(map[string]gqlstruct.FieldInfo)
+
+		middleware := func(next graphql.FieldResolveFn, info gqlstruct.FieldInfo) graphql.FieldResolveFn {
+			return func(p graphql.ResolveParams) (interface{}, error) {
+				infos[info.Struct.Name()+"."+info.Tag.Name] = info
+				r, err := next(p)
+				if s, ok := r.(string); ok {
+					return "<" + s + ">", err
+				}
+				return r, err
+			}
+		}
+
+		r := graphql.Do(graphql.Params{
+			Schema:        newSchema(middleware),
+			RequestString: `{ user { id name nickname greeting } }`,
+		})
+		Expect(r.Errors).To(BeEmpty())
+		Expect(r.Data).To(Equal(map[string]interface{}{
+			"user": map[string]interface{}{
+				"id":       "<1>",
+				"name":     "<John>",
+				"nickname": "<jd>",
+				"greeting": "<Hello John>",
+			},
+		}))
+
+		Expect(infos).To(HaveLen(5))
+		Expect(infos).To(HaveKey("MwQuery.user"))
+
+		name := infos["MwUser.name"]
+		Expect(name.Struct).To(Equal(reflect.TypeOf(MwUser{})))
+		Expect(name.Field.Name).To(Equal("Name"))
+		Expect(name.Method).To(BeNil())
+		Expect(name.Tag).To(Equal(gqlstruct.Tag{Name: "name"}))
+
+		id := infos["MwUser.id"]
+		Expect(id.Field.Name).To(Equal("ID"))
+		Expect(id.Field.Index).To(Equal([]int{0, 0}))
+		Expect(id.Tag).To(Equal(gqlstruct.Tag{Name: "id", NonNull: true}))
+
+		greeting := infos["MwUser.greeting"]
+		Expect(greeting.Field.Name).To(BeEmpty())
+		Expect(greeting.Method.Name).To(Equal("Greeting"))
+	})
+
+	It("should run the middlewares in the order they were added", func() {
+		var calls []string
+		middleware := func(name string) gqlstruct.Middleware {
+			return func(next graphql.FieldResolveFn, info gqlstruct.FieldInfo) graphql.FieldResolveFn {
+				return func(p graphql.ResolveParams) (interface{}, error) {
+					calls = append(calls, name+":"+info.Tag.Name)
+					return next(p)
+				}
+			}
+		}
+
+		r := graphql.Do(graphql.Params{
+			Schema:        newSchema(middleware("first"), middleware("second"), middleware("third")),
+			RequestString: `{ user { name } }`,
+		})
+		Expect(r.Errors).To(BeEmpty())
+		Expect(calls).To(Equal([]string{
+			"first:user", "second:user", "third:user",
+			"first:name", "second:name", "third:name",
+		}))
+	})
+
+	It("should wrap the resolvers of the custom types", func() {
+		recovery := func(next graphql.FieldResolveFn, info gqlstruct.FieldInfo) graphql.FieldResolveFn {
+			return func(p graphql.ResolveParams) (r interface{}, err error) {
+				defer func() {
+					if rec := recover(); rec != nil {
+						err = fmt.Errorf("%s: %v", info.Field.Name, rec)
+					}
+				}()
+				return next(p)
+			}
+		}
+
+		r := graphql.Do(graphql.Params{
+			Schema:        newSchema(recovery),
+			RequestString: `{ user { custom } }`,
+		})
+		Expect(r.Errors).To(HaveLen(1))
+		Expect(r.Errors[0].Message).To(Equal("Custom: only to catch"))
+	})
+
+	It("should not wrap the fields of the objects already built", func() {
+		enc := gqlstruct.NewEncoder()
+		_, err := enc.Struct(MwUser{})
+		Expect(err).ToNot(HaveOccurred())
+
+		enc.Use(func(next graphql.FieldResolveFn, info gqlstruct.FieldInfo) graphql.FieldResolveFn {
+			panic("should not be called")
+		})
+		obj, err := enc.Struct(MwUser{})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(obj.Fields()["name"].Resolve(graphql.ResolveParams{Source: &MwUser{Name: "John"}})).To(Equal("John"))
+	})
+})
@@ -0,0 +1,180 @@
+package gqlstruct
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/graphql-go/graphql"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// Policy checks if the field of the source (the value of the object being
+// resolved) can be resolved. It returns nil when the access is granted.
+type Policy func(ctx context.Context, source interface{}) error
+
+// ErrMaskField is returned by policies to mask the field: it is resolved as
+// null, without errors.
+var ErrMaskField = errors.New("the field is masked")
+
+// authDirective is the SDL definition of the directive that describes the
+// policies of the fields.
+const authDirective = "directive @auth(policies: [String!]!) on FIELD_DEFINITION"
+
+// RegisterPolicy registers the policy in the default encoder (check the
+// encoder `RegisterPolicy`).
+func RegisterPolicy(name string, policy Policy) {
+	defaultEncoder.RegisterPolicy(name, policy)
+}
+
+// RegisterPolicy registers the policy, by its name, to protect the fields
+// tagged with it by the "auth" tag:
+//
+// ```
+// Email string `graphql:"email" auth:"admin,self"`
+// ```
+//
+// The field is resolved when any of its policies grants the access.
+// Otherwise, it is resolved as null: masked, without errors, when any policy
+// returns `ErrMaskField`, or with the error of the first policy. So, the
+// fields protected must be nullable.
+//
+// The policies must be registered before the objects that use them are
+// built.
+func (enc *encoder) RegisterPolicy(name string, policy Policy) {
+	defer enc.lock()()
+	if enc.policies == nil {
+		enc.policies = make(map[string]Policy)
+	}
+	enc.policies[name] = policy
+}
+
+// applyPolicies wraps the resolvers of the fields of the struct tagged with
+// policies, and records the policies of the fields of the object.
+func (enc *encoder) applyPolicies(obj *graphql.Object, t reflect.Type, fields graphql.Fields, infos map[string]FieldInfo) error {
+	// The fields are sorted, so the errors are always reported in the same
+	// order.
+	names := make([]string, 0, len(fields))
+	for name := range fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	fieldPolicies := make(map[string][]string)
+	for _, name := range names {
+		info, ok := infos[name]
+		if !ok {
+			continue
+		}
+		// Methods have no struct tags, their "auth" option is rejected by
+		// `buildMethodFields`.
+		tag, ok := info.Field.Tag.Lookup("auth")
+		if !ok {
+			continue
+		}
+		policyNames, policies, err := enc.parsePolicies(tag)
+		if _, ok := fields[name].Type.(*graphql.NonNull); ok && err == nil {
+			// The fields masked are resolved as null.
+			err = errors.New("the fields protected by policies must be nullable")
+		}
+		if err != nil {
+			if err := enc.fieldError(name, info.Field.Type, fmt.Errorf("%s.%s: %s", t.Name(), info.Field.Name, err.Error())); err != nil {
+				return err
+			}
+			delete(fields, name)
+			continue
+		}
+
+		field := fields[name]
+		field.Resolve = policiesResolve(policies, field.Resolve)
+		fieldPolicies[name] = policyNames
+	}
+	if len(fieldPolicies) > 0 {
+		enc.setPolicies(obj, fieldPolicies)
+	}
+	return nil
+}
+
+// setPolicies records the policies of the fields of the object, so they are
+// printed.
+func (enc *encoder) setPolicies(obj *graphql.Object, fieldPolicies map[string][]string) {
+	enc.fieldPolicies[obj] = fieldPolicies
+	enc.onRollback(func() {
+		delete(enc.fieldPolicies, obj)
+	})
+}
+
+// parsePolicies returns the names and the policies of the "auth" tag.
+func (enc *encoder) parsePolicies(tag string) ([]string, []Policy, error) {
+	var names []string
+	var policies []Policy
+	for _, name := range strings.Split(tag, ",") {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		policy, ok := enc.policies[name]
+		if !ok {
+			return nil, nil, fmt.Errorf("the policy '%s' is not registered", name)
+		}
+		names = append(names, name)
+		policies = append(policies, policy)
+	}
+	if len(policies) == 0 {
+		return nil, nil, errors.New("the auth tag has no policies")
+	}
+	return names, policies, nil
+}
+
+// policiesResolve creates a resolver that only calls resolve when any of the
+// policies grants the access.
+func policiesResolve(policies []Policy, resolve graphql.FieldResolveFn) graphql.FieldResolveFn {
+	if resolve == nil {
+		resolve = graphql.DefaultResolveFn
+	}
+	return func(p graphql.ResolveParams) (interface{}, error) {
+		var first error
+		masked := false
+		for _, policy := range policies {
+			err := policy(p.Context, p.Source)
+			if err == nil {
+				return resolve(p)
+			}
+			if err == ErrMaskField {
+				masked = true
+			} else if first == nil {
+				first = err
+			}
+		}
+		if masked {
+			return nil, nil
+		}
+		return nil, first
+	}
+}
+
+// hasPolicies checks if any field of the objects has policies, so the
+// `@auth` directive is printed.
+func (enc *encoder) hasPolicies(types map[string]graphql.Type) bool {
+	for _, t := range types {
+		if obj, ok := t.(*graphql.Object); ok && len(enc.fieldPolicies[obj]) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// printPolicies prints the policies of the field of the object as an `@auth`
+// directive.
+func (enc *encoder) printPolicies(obj *graphql.Object, field string) string {
+	names := enc.fieldPolicies[obj][field]
+	if len(names) == 0 {
+		return ""
+	}
+	quoted := make([]string, len(names))
+	for i, name := range names {
+		quoted[i] = printString(name)
+	}
+	return fmt.Sprintf(" @auth(policies: [%s])", strings.Join(quoted, ", "))
+}
@@ -0,0 +1,201 @@
+package gqlstruct_test
+
+import (
+	"context"
+	"errors"
+	"github.com/graphql-go/graphql"
+	"github.com/lab259/go-graphql-struct"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+type AuthUser struct {
+	Node  `graphql:",interface"`
+	Name  string `graphql:"name"`
+	Email string `graphql:"email" auth:"admin,self"`
+	Phone string `graphql:"phone" auth:"admin, masked"`
+}
+
+type AuthQuery struct {
+	User *AuthUser `graphql:"user"`
+}
+
+type AuthAccount struct {
+	Name string `graphql:"name"`
+}
+
+func (*AuthAccount) Secret() string {
+	return "secret"
+}
+
+type viewerKey struct{}
+
+var _ = Describe("Policies", func() {
+	newEncoder := func() interface {
+		Struct(interface{}, ...gqlstruct.StructOption) (*graphql.Object, error)
+		Schema(interface{}, interface{}, interface{}) (graphql.Schema, error)
+		PrintSDL() string
+		PrintSchema(graphql.Schema) string
+	} {
+		enc := gqlstruct.NewEncoder()
+		enc.RegisterPolicy("admin", func(ctx context.Context, source interface{}) error {
+			if ctx.Value(viewerKey{}) != "admin" {
+				return errors.New("only admins can see it")
+			}
+			return nil
+		})
+		enc.RegisterPolicy("self", func(ctx context.Context, source interface{}) error {
+			if ctx.Value(viewerKey{}) != source.(*AuthUser).ID {
+				return errors.New("only the user can see it")
+			}
+			return nil
+		})
+		enc.RegisterPolicy("masked", func(ctx context.Context, source interface{}) error {
+			return gqlstruct.ErrMaskField
+		})
+		return enc
+	}
+
+	query := func(viewer string) *graphql.Result {
+		schema, err := newEncoder().Schema(&AuthQuery{
+			User: &AuthUser{Node: Node{ID: "1"}, Name: "John", Email: "john@example.com", Phone: "555-0100"},
+		}, nil, nil)
+		Expect(err).ToNot(HaveOccurred())
+		return graphql.Do(graphql.Params{
+			Schema:        schema,
+			RequestString: `{ user { name email phone } }`,
+			Context:       context.WithValue(context.Background(), viewerKey{}, viewer),
+		})
+	}
+
+	It("should resolve the fields when any policy grants the access", func() {
+		for _, viewer := range []string{"admin", "1"} {
+			r := query(viewer)
+			Expect(r.Errors).To(BeEmpty())
+			Expect(r.Data.(map[string]interface{})["user"].(map[string]interface{})["email"]).To(Equal("john@example.com"))
+		}
+
+		r := query("admin")
+		Expect(r.Data.(map[string]interface{})["user"].(map[string]interface{})["phone"]).To(Equal("555-0100"))
+	})
+
+	It("should resolve the fields as null with the error of the first policy", func() {
+		r := query("2")
+		Expect(r.Errors).To(HaveLen(1))
+		Expect(r.Errors[0].Message).To(Equal("only admins can see it"))
+		Expect(r.Errors[0].Path).To(Equal([]interface{}{"user", "email"}))
+		Expect(r.Data).To(Equal(map[string]interface{}{
+			"user": map[string]interface{}{
+				"name":  "John",
+				"email": nil,
+				"phone": nil,
+			},
+		}))
+	})
+
+	It("should mask the fields", func() {
+		r := graphql.Do(graphql.Params{
+			Schema: func() graphql.Schema {
+				schema, err := newEncoder().Schema(&AuthQuery{User: &AuthUser{Phone: "555-0100"}}, nil, nil)
+				Expect(err).ToNot(HaveOccurred())
+				return schema
+			}(),
+			RequestString: `{ user { phone } }`,
+			Context:       context.WithValue(context.Background(), viewerKey{}, "1"),
+		})
+		Expect(r.Errors).To(BeEmpty())
+		Expect(r.Data).To(Equal(map[string]interface{}{
+			"user": map[string]interface{}{"phone": nil},
+		}))
+	})
+
+	It("should print the policies as directives", func() {
+		enc := newEncoder()
+		_, err := enc.Struct(AuthUser{})
+		Expect(err).ToNot(HaveOccurred())
+
+		sdl := enc.PrintSDL()
+		Expect(sdl).To(HavePrefix("directive @auth(policies: [String!]!) on FIELD_DEFINITION\n\n"))
+		Expect(sdl).To(ContainSubstring(`type AuthUser implements Node {
+  email: String @auth(policies: ["admin", "self"])
+  id: String!
+  name: String
+  phone: String @auth(policies: ["admin", "masked"])
+}`))
+		Expect(sdl).To(ContainSubstring(`interface Node {
+  id: String!
+}`))
+	})
+
+	It("should print the policies of the schemas", func() {
+		enc := newEncoder()
+		schema, err := enc.Schema(&AuthQuery{}, nil, nil)
+		Expect(err).ToNot(HaveOccurred())
+
+		sdl := enc.PrintSchema(schema)
+		Expect(sdl).To(HavePrefix("directive @auth(policies: [String!]!) on FIELD_DEFINITION\n\nschema {\n  query: AuthQuery\n}\n\n"))
+		Expect(sdl).To(ContainSubstring(`  email: String @auth(policies: ["admin", "self"])`))
+	})
+
+	It("should only print the policies of the objects built by the encoder", func() {
+		schema, err := newEncoder().Schema(&AuthQuery{}, nil, nil)
+		Expect(err).ToNot(HaveOccurred())
+
+		sdl := newEncoder().PrintSchema(schema)
+		Expect(sdl).To(HavePrefix("schema {\n"))
+		Expect(sdl).To(ContainSubstring("  email: String\n"))
+		Expect(sdl).ToNot(ContainSubstring("@auth"))
+	})
+
+	It("should fail when a non-null field has policies", func() {
+		type Account struct {
+			Email string `graphql:"!email" auth:"admin"`
+		}
+
+		_, err := newEncoder().Struct(Account{})
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(Equal("Account.Email: the fields protected by policies must be nullable"))
+	})
+
+	It("should fail when the policy is not registered", func() {
+		type Account struct {
+			Email string `graphql:"email" auth:"admin,unknown"`
+		}
+
+		_, err := newEncoder().Struct(Account{})
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(Equal("Account.Email: the policy 'unknown' is not registered"))
+	})
+
+	It("should fail when the auth tag has no policies", func() {
+		type Account struct {
+			Email string `graphql:"email" auth:""`
+		}
+
+		_, err := newEncoder().Struct(Account{})
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(Equal("Account.Email: the auth tag has no policies"))
+	})
+
+	It("should skip the fields with invalid policies when aggregating the errors", func() {
+		type Account struct {
+			Name  string `graphql:"name"`
+			Email string `graphql:"email" auth:"unknown"`
+		}
+
+		enc := gqlstruct.NewEncoder(gqlstruct.WithAggregatedErrors())
+		_, err := enc.Struct(Account{})
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("Account.email"))
+		Expect(err.Error()).To(ContainSubstring("the policy 'unknown' is not registered"))
+	})
+
+	It("should fail when a method has policies", func() {
+		_, err := newEncoder().Struct(AuthAccount{}, gqlstruct.WithMethod("Secret", "secret,auth=admin"))
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(Equal("AuthAccount.Secret: invalid tag `secret,auth=admin`: methods do not support policies"))
+
+		var invalidTag *gqlstruct.InvalidTagError
+		Expect(errors.As(err, &invalidTag)).To(BeTrue())
+	})
+})
@@ -0,0 +1,255 @@
+package gqlstruct
+
+import (
+	"fmt"
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/language/ast"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// ProjectedField is a Go field of a model requested by a query.
+type ProjectedField struct {
+	// Name is the name of the Go field ("CreatedAt").
+	Name string
+	// Column is the name in the `db` tag of the Go field ("created_at"),
+	// empty when the field has no `db` tag.
+	Column string
+}
+
+// ProjectedFields is the set of Go fields of a model requested by a query,
+// indexed by their names.
+type ProjectedFields map[string]ProjectedField
+
+// Has checks if the Go field was requested.
+func (fields ProjectedFields) Has(name string) bool {
+	_, ok := fields[name]
+	return ok
+}
+
+// Names returns the names of the Go fields requested, sorted.
+func (fields ProjectedFields) Names() []string {
+	r := make([]string, 0, len(fields))
+	for name := range fields {
+		r = append(r, name)
+	}
+	sort.Strings(r)
+	return r
+}
+
+// Columns returns the names in the `db` tags of the Go fields requested,
+// sorted. The fields without a `db` tag are ignored.
+func (fields ProjectedFields) Columns() []string {
+	r := make([]string, 0, len(fields))
+	for _, field := range fields {
+		if field.Column != "" {
+			r = append(r, field.Column)
+		}
+	}
+	sort.Strings(r)
+	return r
+}
+
+// Projection returns the Go fields of the model requested by the query
+// being resolved, so the resolver fetches only them:
+//
+// ```
+// fields, err := gqlstruct.Projection(p, User{})
+// rows, err := db.Query("SELECT " + strings.Join(fields.Columns(), ", ") + " FROM users")
+// ```
+//
+// The fields are collected from the selection of the field resolved,
+// including its fragments (skipped ones aside) and, for connections, from the
+// selection of the nodes of their edges. The GraphQL names are mapped back to
+// the Go fields by their "graphql" tags, including the fields promoted from
+// the embedded interfaces. The GraphQL fields that are not Go fields of the
+// model (as methods) are ignored.
+func Projection(p graphql.ResolveParams, model interface{}) (ProjectedFields, error) {
+	return defaultEncoder.Projection(p, model)
+}
+
+// Projection returns the Go fields of the model requested by the query being
+// resolved. The fields are mapped the same way the encoder describes them
+// (check `NewEncoder`), so it must be the encoder that built the schema.
+func (enc *encoder) Projection(p graphql.ResolveParams, model interface{}) (ProjectedFields, error) {
+	t := reflect.TypeOf(model)
+	for t != nil && t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t == nil || t.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("cannot project %T, it is not a struct", model)
+	}
+
+	unlock := enc.lock()
+	obj, _ := unwrapNonNull(p.Info.ReturnType).(*graphql.Object)
+	isConnection := obj != nil && enc.isConnection(obj)
+	modelType, _ := enc.getType(t)
+	unlock()
+
+	// The fragments are followed when their type conditions match the type
+	// of the model, which is the type of the fields selected (or of the
+	// nodes of the connections).
+	parent, _ := graphql.GetNamed(p.Info.ReturnType).(graphql.Type)
+	if isConnection {
+		parent = fieldType(fieldType(obj, "edges"), "node")
+	}
+	if _, ok := parent.(*graphql.Object); !ok {
+		// The abstract types are resolved as the object of the model, when
+		// built by the encoder.
+		if modelObj, ok := modelType.(*graphql.Object); ok {
+			parent = modelObj
+		}
+	}
+
+	var selected map[string][]*ast.Field
+	if isConnection {
+		edges := selectedFields(p, p.Info.FieldASTs, obj)["edges"]
+		selected = selectedFields(p, selectedFields(p, edges, fieldType(obj, "edges"))["node"], parent)
+	} else {
+		selected = selectedFields(p, p.Info.FieldASTs, parent)
+	}
+
+	r := make(ProjectedFields)
+	for name, index := range enc.config.fieldIndexes(t) {
+		if _, ok := selected[name]; !ok {
+			continue
+		}
+		field := t.FieldByIndex(index)
+		r[field.Name] = ProjectedField{
+			Name:   field.Name,
+			Column: columnOf(field),
+		}
+	}
+	return r, nil
+}
+
+// unwrapNonNull returns the type wrapped by a non null type.
+func unwrapNonNull(t graphql.Type) graphql.Type {
+	if nonNull, ok := t.(*graphql.NonNull); ok {
+		return nonNull.OfType
+	}
+	return t
+}
+
+// columnOf returns the name in the `db` tag of the field.
+func columnOf(field reflect.StructField) string {
+	column := strings.Split(field.Tag.Get("db"), ",")[0]
+	if column == "-" {
+		return ""
+	}
+	return column
+}
+
+// selectedFields collects the fields selected by the fields informed,
+// indexed by their names (not their aliases). The fragments whose type
+// conditions match the parent type (the type of the fields informed) are
+// followed and the selections skipped by the `@skip` and `@include`
+// directives are ignored.
+func selectedFields(p graphql.ResolveParams, fields []*ast.Field, parent graphql.Type) map[string][]*ast.Field {
+	r := make(map[string][]*ast.Field)
+	visited := make(map[string]bool)
+	for _, field := range fields {
+		collectFields(p, field.SelectionSet, parent, r, visited)
+	}
+	return r
+}
+
+// collectFields adds the fields of the selection set to r.
+func collectFields(p graphql.ResolveParams, selectionSet *ast.SelectionSet, parent graphql.Type, r map[string][]*ast.Field, visited map[string]bool) {
+	if selectionSet == nil {
+		return
+	}
+	for _, selection := range selectionSet.Selections {
+		switch s := selection.(type) {
+		case *ast.Field:
+			if included(p, s.Directives) {
+				r[s.Name.Value] = append(r[s.Name.Value], s)
+			}
+		case *ast.InlineFragment:
+			if included(p, s.Directives) && fragmentMatches(p, s.TypeCondition, parent) {
+				collectFields(p, s.SelectionSet, parent, r, visited)
+			}
+		case *ast.FragmentSpread:
+			name := s.Name.Value
+			if visited[name] || !included(p, s.Directives) {
+				continue
+			}
+			fragment, ok := p.Info.Fragments[name].(*ast.FragmentDefinition)
+			if !ok || !fragmentMatches(p, fragment.TypeCondition, parent) {
+				continue
+			}
+			visited[name] = true
+			collectFields(p, fragment.SelectionSet, parent, r, visited)
+		}
+	}
+}
+
+// fragmentMatches checks if the type condition of a fragment applies to the
+// parent type: the type itself or, for objects, the interfaces and unions
+// they belong to. When the parent is not an object (or unknown), its
+// objects are not known, so all fragments are followed.
+func fragmentMatches(p graphql.ResolveParams, condition *ast.Named, parent graphql.Type) bool {
+	if condition == nil || parent == nil || condition.Name.Value == parent.Name() {
+		return true
+	}
+	obj, ok := parent.(*graphql.Object)
+	if !ok {
+		return true
+	}
+	schema := p.Info.Schema
+	abstract, ok := schema.Type(condition.Name.Value).(graphql.Abstract)
+	return ok && schema.IsPossibleType(abstract, obj)
+}
+
+// fieldType returns the named type of the field of the parent type, or nil
+// when it is not known.
+func fieldType(parent graphql.Type, name string) graphql.Type {
+	var fields graphql.FieldDefinitionMap
+	switch t := parent.(type) {
+	case *graphql.Object:
+		fields = t.Fields()
+	case *graphql.Interface:
+		fields = t.Fields()
+	}
+	field, ok := fields[name]
+	if !ok {
+		return nil
+	}
+	r, _ := graphql.GetNamed(field.Type).(graphql.Type)
+	return r
+}
+
+// included checks the `@skip` and `@include` directives of a selection.
+func included(p graphql.ResolveParams, directives []*ast.Directive) bool {
+	for _, directive := range directives {
+		switch directive.Name.Value {
+		case "skip":
+			if directiveIf(p, directive) {
+				return false
+			}
+		case "include":
+			if !directiveIf(p, directive) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// directiveIf returns the value of the `if` argument of the directive.
+func directiveIf(p graphql.ResolveParams, directive *ast.Directive) bool {
+	for _, arg := range directive.Arguments {
+		if arg.Name.Value != "if" {
+			continue
+		}
+		switch v := arg.Value.(type) {
+		case *ast.BooleanValue:
+			return v.Value
+		case *ast.Variable:
+			value, _ := p.Info.VariableValues[v.Name.Value].(bool)
+			return value
+		}
+	}
+	return false
+}
@@ -0,0 +1,172 @@
+package gqlstruct_test
+
+import (
+	"github.com/graphql-go/graphql"
+	"github.com/lab259/go-graphql-struct"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"reflect"
+	"time"
+)
+
+type ProjUser struct {
+	Node      `graphql:",interface"`
+	Name      string    `graphql:"name" db:"name"`
+	Email     string    `graphql:"email"`
+	CreatedAt time.Time `graphql:"createdAt" db:"created_at,omitempty"`
+	Password  string    `graphql:"-" db:"password"`
+}
+
+func (u *ProjUser) GraphqlMethods() map[string]string {
+	return map[string]string{
+		"Greeting": "greeting",
+	}
+}
+
+func (u *ProjUser) Greeting() string {
+	return "Hello " + u.Name
+}
+
+type ProjGroup struct {
+	Node  `graphql:",interface"`
+	Email string `graphql:"email"`
+	Title string `graphql:"title"`
+}
+
+type ProjNodeQuery struct {
+	Node *Node `graphql:"node"`
+}
+
+type ProjQuery struct {
+	User  *ProjUser  `graphql:"user"`
+	Users []ProjUser `graphql:"users,connection"`
+}
+
+var _ = Describe("Projection", func() {
+	var fields gqlstruct.ProjectedFields
+
+	run := func(query string, variables map[string]interface{}) {
+		fields = nil
+
+		enc := gqlstruct.NewEncoder()
+		_, err := enc.Struct(ProjQuery{},
+			gqlstruct.WithFieldOptions("user", gqlstruct.WithResolve(func(p graphql.ResolveParams) (interface{}, error) {
+				var err error
+				fields, err = enc.Projection(p, ProjUser{})
+				return &ProjUser{}, err
+			})),
+			gqlstruct.WithFieldOptions("users", gqlstruct.WithConnectionResolve(func(p graphql.ResolveParams, args gqlstruct.ConnectionArgs) (*gqlstruct.Connection, error) {
+				var err error
+				fields, err = enc.Projection(p, &ProjUser{})
+				return &gqlstruct.Connection{}, err
+			})),
+		)
+		Expect(err).ToNot(HaveOccurred())
+		schema, err := enc.Schema(&ProjQuery{}, nil, nil)
+		Expect(err).ToNot(HaveOccurred())
+
+		r := graphql.Do(graphql.Params{
+			Schema:         schema,
+			RequestString:  query,
+			VariableValues: variables,
+		})
+		Expect(r.Errors).To(BeEmpty())
+	}
+
+	It("should return the Go fields requested", func() {
+		run(`{ user { userName: name createdAt greeting __typename } }`, nil)
+		Expect(fields).To(Equal(gqlstruct.ProjectedFields{
+			"Name":      {Name: "Name", Column: "name"},
+			"CreatedAt": {Name: "CreatedAt", Column: "created_at"},
+		}))
+		Expect(fields.Has("Name")).To(BeTrue())
+		Expect(fields.Has("Email")).To(BeFalse())
+		Expect(fields.Names()).To(Equal([]string{"CreatedAt", "Name"}))
+		Expect(fields.Columns()).To(Equal([]string{"created_at", "name"}))
+	})
+
+	It("should follow the fragments", func() {
+		run(`
+			query {
+				user {
+					...UserFields
+					... on Node { id }
+				}
+			}
+
+			fragment UserFields on ProjUser {
+				email
+				...UserFields2
+			}
+
+			fragment UserFields2 on ProjUser {
+				name
+			}`, nil)
+		Expect(fields.Names()).To(Equal([]string{"Email", "ID", "Name"}))
+		Expect(fields.Columns()).To(Equal([]string{"name"}))
+	})
+
+	It("should skip the fragments of other types", func() {
+		enc := gqlstruct.NewEncoder()
+		// The interface is built first, so the node field is described as
+		// the interface.
+		_, err := enc.InterfaceOf(reflect.TypeOf(Node{}))
+		Expect(err).ToNot(HaveOccurred())
+		_, err = enc.Struct(ProjUser{})
+		Expect(err).ToNot(HaveOccurred())
+		_, err = enc.Struct(ProjGroup{})
+		Expect(err).ToNot(HaveOccurred())
+		_, err = enc.Struct(ProjNodeQuery{}, gqlstruct.WithFieldOptions("node", gqlstruct.WithResolve(func(p graphql.ResolveParams) (interface{}, error) {
+			var err error
+			fields, err = enc.Projection(p, ProjUser{})
+			return &ProjUser{Node: Node{ID: "1"}}, err
+		})))
+		Expect(err).ToNot(HaveOccurred())
+		schema, err := enc.Schema(&ProjNodeQuery{}, nil, nil)
+		Expect(err).ToNot(HaveOccurred())
+
+		r := graphql.Do(graphql.Params{
+			Schema: schema,
+			RequestString: `
+				query {
+					node {
+						id
+						... on ProjUser { name }
+						... on ProjGroup { email title }
+						...GroupFields
+					}
+				}
+
+				fragment GroupFields on ProjGroup {
+					email
+				}`,
+		})
+		Expect(r.Errors).To(BeEmpty())
+		Expect(fields.Names()).To(Equal([]string{"ID", "Name"}))
+	})
+
+	It("should ignore the selections skipped", func() {
+		run(`query ($withName: Boolean!) {
+			user {
+				id
+				name @include(if: $withName)
+				email @skip(if: true)
+				... @include(if: false) { createdAt }
+			}
+		}`, map[string]interface{}{"withName": false})
+		Expect(fields.Names()).To(Equal([]string{"ID"}))
+
+		run(`query ($withName: Boolean!) { user { name @include(if: $withName) email @skip(if: false) } }`, map[string]interface{}{"withName": true})
+		Expect(fields.Names()).To(Equal([]string{"Email", "Name"}))
+	})
+
+	It("should return the fields of the nodes of the connections", func() {
+		run(`{ users(first: 10) { pageInfo { hasNextPage } edges { cursor node { name } } edges { node { email } } } }`, nil)
+		Expect(fields.Names()).To(Equal([]string{"Email", "Name"}))
+	})
+
+	It("should fail when the model is not a struct", func() {
+		_, err := gqlstruct.Projection(graphql.ResolveParams{}, 1)
+		Expect(err).To(MatchError("cannot project int, it is not a struct"))
+	})
+})
@@ -1,6 +1,9 @@
 package gqlstruct
 
 import (
+	"context"
+	"errors"
+	"fmt"
 	"github.com/graphql-go/graphql"
 	"reflect"
 )
@@ -13,8 +16,12 @@ type GraphqlResolver interface {
 	GraphqlResolve(p graphql.ResolveParams) (interface{}, error)
 }
 
-func fieldResolve(field reflect.StructField) graphql.FieldResolveFn {
-	t := field.Type
+// fieldResolve creates the resolver of the field, in the given index, of the
+// structType: the resolver of its type, when it implements
+// `GraphqlResolver` or `GraphqlBatchResolver`, or a resolver that reads the
+// field (check `indexResolve`).
+func fieldResolve(structType reflect.Type, index int) graphql.FieldResolveFn {
+	t := structType.Field(index).Type
 
 	if t.Kind() == reflect.Struct {
 		// If the type is a struct, we need the a pointer to that struct to
@@ -31,5 +38,220 @@ func fieldResolve(field reflect.StructField) graphql.FieldResolveFn {
 		return vStruct.Interface().(GraphqlResolver).GraphqlResolve
 	}
 
-	return nil
+	// Types resolved in batches are checked the same way, including the
+	// pointers of the types that are not structs.
+	if t.Kind() != reflect.Ptr && reflect.PtrTo(t).Implements(graphqlBatchResolverType) {
+		return batchResolve(reflect.New(t).Interface().(GraphqlBatchResolver).GraphqlBatchResolve)
+	}
+	if t.Implements(graphqlBatchResolverType) {
+		return batchResolve(reflect.New(t).Elem().Interface().(GraphqlBatchResolver).GraphqlBatchResolve)
+	}
+
+	return indexResolve(structType, index)
+}
+
+// indexResolve creates a resolver that reads the field, in the given index,
+// of the structType. Unlike `graphql.DefaultResolveFn`, that matches the
+// fields by their Go names or their "json" and "graphql" tags, it works with
+// any tag key or naming strategy of the encoder.
+//
+// Sources of other types are resolved by `graphql.DefaultResolveFn`.
+func indexResolve(structType reflect.Type, index int) graphql.FieldResolveFn {
+	return func(p graphql.ResolveParams) (interface{}, error) {
+		v := reflect.ValueOf(p.Source)
+		if v.Kind() == reflect.Ptr {
+			if v.IsNil() {
+				return nil, nil
+			}
+			v = v.Elem()
+		}
+		if !v.IsValid() || v.Type() != structType {
+			return graphql.DefaultResolveFn(p)
+		}
+		field := v.Field(index)
+		if !field.CanInterface() {
+			// Unexported fields cannot be read.
+			return nil, nil
+		}
+		return field.Interface(), nil
+	}
+}
+
+// embeddedFieldResolve creates a resolver for the fields promoted from the
+// embedded struct, in the given index, of the structType. The resolve is
+// called with the embedded struct as source.
+//
+// If resolve is nil, the `graphql.DefaultResolveFn` is used.
+func embeddedFieldResolve(structType reflect.Type, index int, resolve graphql.FieldResolveFn) graphql.FieldResolveFn {
+	if resolve == nil {
+		resolve = graphql.DefaultResolveFn
+	}
+	return func(p graphql.ResolveParams) (interface{}, error) {
+		v := reflect.ValueOf(p.Source)
+		if v.Kind() == reflect.Ptr && !v.IsNil() {
+			v = v.Elem()
+		}
+		if !v.IsValid() || v.Type() != structType {
+			// The source is not the struct the field was built from, so the
+			// resolution is left to resolve.
+			return resolve(p)
+		}
+		embedded := v.Field(index)
+		if embedded.Kind() == reflect.Ptr && embedded.IsNil() {
+			return nil, nil
+		}
+		if !embedded.CanInterface() {
+			return resolve(p)
+		}
+		p.Source = embedded.Interface()
+		return resolve(p)
+	}
+}
+
+// isTypeOf creates a `graphql.IsTypeOfFn` that checks if the value is of the
+// type informed (or a pointer to it).
+func isTypeOf(t reflect.Type) graphql.IsTypeOfFn {
+	return func(p graphql.IsTypeOfParams) bool {
+		vt := reflect.TypeOf(p.Value)
+		if vt != nil && vt.Kind() == reflect.Ptr {
+			vt = vt.Elem()
+		}
+		return vt == t
+	}
+}
+
+// resolveTypeOf creates a `graphql.ResolveTypeFn` that resolves the object by
+// the Go type of the value (or the type it points to).
+func resolveTypeOf(types map[reflect.Type]*graphql.Object) graphql.ResolveTypeFn {
+	return func(p graphql.ResolveTypeParams) *graphql.Object {
+		t := reflect.TypeOf(p.Value)
+		if t != nil && t.Kind() == reflect.Ptr {
+			t = t.Elem()
+		}
+		return types[t]
+	}
+}
+
+// Resolver creates a `graphql.Field` from a typed resolver function:
+//
+// ```
+// func(ctx context.Context, src *Parent, args MyArgs) (*Result, error)
+// ```
+//
+// The arguments are described by `ArgsOf` on the type of the args parameter,
+// which is optional, and the type of the field is described by the type of
+// the result. When resolving, the `p.Args` are decoded into the args and the
+// `p.Source` is converted to the type of the src parameter.
+//
+// The error result is optional.
+func (enc *encoder) Resolver(fn interface{}, options ...FieldOption) (graphql.Field, error) {
+	defer enc.lock()()
+	r, err := enc.resolver(fn, options...)
+	if err := enc.endBuild(err); err != nil {
+		return graphql.Field{}, err
+	}
+	return r, nil
+}
+
+// resolver implements `Resolver`, without locking the encoder.
+func (enc *encoder) resolver(fn interface{}, options ...FieldOption) (graphql.Field, error) {
+	fnValue := reflect.ValueOf(fn)
+	if !fnValue.IsValid() {
+		return graphql.Field{}, errors.New("the resolver must be a function, got nil")
+	}
+	ft := fnValue.Type()
+	if ft.Kind() != reflect.Func {
+		return graphql.Field{}, fmt.Errorf("the resolver must be a function, got %s", ft)
+	}
+	if fnValue.IsNil() {
+		return graphql.Field{}, fmt.Errorf("the resolver must be a function, got a nil %s", ft)
+	}
+
+	if ft.NumIn() < 2 || ft.NumIn() > 3 || ft.In(0) != contextType {
+		return graphql.Field{}, errors.New("the resolver must receive a context.Context, the source and, optionally, the arguments")
+	}
+
+	if ft.NumOut() == 0 || ft.NumOut() > 2 || ft.Out(0) == errorType || (ft.NumOut() == 2 && ft.Out(1) != errorType) {
+		return graphql.Field{}, errors.New("the resolver must return a value and, optionally, an error")
+	}
+
+	fieldType, err := enc.buildFieldType(ft.Out(0))
+	if err != nil {
+		return graphql.Field{}, err
+	}
+
+	r := graphql.Field{
+		Type: fieldType,
+	}
+
+	sourceType := ft.In(1)
+	var argsType reflect.Type
+	if ft.NumIn() == 3 {
+		argsType = ft.In(2)
+		args, err := enc.argsOf(argsType)
+		if err != nil {
+			return graphql.Field{}, err
+		}
+		r.Args = args
+	}
+
+	r.Resolve = func(p graphql.ResolveParams) (interface{}, error) {
+		ctx := p.Context
+		if ctx == nil {
+			ctx = context.Background()
+		}
+
+		source, err := convertSource(p.Source, sourceType)
+		if err != nil {
+			return nil, err
+		}
+
+		in := []reflect.Value{reflect.ValueOf(&ctx).Elem(), source}
+		if argsType != nil {
+			args, err := enc.config.newArgs(argsType, p.Args)
+			if err != nil {
+				return nil, err
+			}
+			in = append(in, args)
+		}
+		return callResult(fnValue.Call(in))
+	}
+
+	if err := enc.applyFieldOptions(&r, options); err != nil {
+		return graphql.Field{}, err
+	}
+
+	return r, nil
+}
+
+// convertSource converts the source of a resolver to the type t, taking the
+// address of or dereferencing it when needed.
+func convertSource(source interface{}, t reflect.Type) (reflect.Value, error) {
+	if source == nil {
+		return reflect.Zero(t), nil
+	}
+
+	v := reflect.ValueOf(source)
+	switch {
+	case v.Type().AssignableTo(t):
+		return v, nil
+	case t.Kind() == reflect.Ptr && v.Type().AssignableTo(t.Elem()):
+		ptr := reflect.New(t.Elem())
+		ptr.Elem().Set(v)
+		return ptr, nil
+	case v.Kind() == reflect.Ptr && v.Type().Elem().AssignableTo(t):
+		if v.IsNil() {
+			return reflect.Zero(t), nil
+		}
+		return v.Elem(), nil
+	}
+	return reflect.Value{}, fmt.Errorf("cannot use %s as the source %s", v.Type(), t)
+}
+
+func Resolver(fn interface{}, options ...FieldOption) graphql.Field {
+	r, err := defaultEncoder.Resolver(fn, options...)
+	if err != nil {
+		panic(err.Error())
+	}
+	return r
 }
@@ -0,0 +1,168 @@
+package gqlstruct_test
+
+import (
+	"context"
+	"errors"
+	"github.com/graphql-go/graphql"
+	"github.com/lab259/go-graphql-struct"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Resolver", func() {
+	type Author struct {
+		Name string `graphql:"name"`
+	}
+
+	type Book struct {
+		Title  string `graphql:"title"`
+		Author string
+	}
+
+	type Address struct {
+		City string `graphql:"!city"`
+	}
+
+	type BooksArgs struct {
+		Title   string   `graphql:"title"`
+		Limit   int      `graphql:"limit,default=10"`
+		Address *Address `graphql:"address"`
+	}
+
+	It("should build a field from a typed resolver", func() {
+		field, err := gqlstruct.NewEncoder().Resolver(func(ctx context.Context, src *Author, args BooksArgs) ([]*Book, error) {
+			return nil, nil
+		}, gqlstruct.WithDescription("Description 1"))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(field.Type.String()).To(Equal("[Book]"))
+		Expect(field.Description).To(Equal("Description 1"))
+		Expect(field.Args).To(HaveLen(3))
+		Expect(field.Args).To(HaveKey("title"))
+		Expect(field.Args).To(HaveKey("limit"))
+		Expect(field.Args["address"].Type.String()).To(Equal("AddressInput"))
+		Expect(field.Resolve).ToNot(BeNil())
+	})
+
+	It("should build a field without arguments", func() {
+		field, err := gqlstruct.NewEncoder().Resolver(func(ctx context.Context, src interface{}) *Author {
+			return nil
+		})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(field.Type.String()).To(Equal("Author"))
+		Expect(field.Args).To(BeEmpty())
+	})
+
+	It("should decode the arguments and convert the source", func() {
+		enc := gqlstruct.NewEncoder()
+		var received BooksArgs
+		booksField, err := enc.Resolver(func(ctx context.Context, src *Author, args BooksArgs) ([]*Book, error) {
+			received = args
+			if args.Title == "" {
+				return nil, errors.New("title is required")
+			}
+			return []*Book{
+				{Title: args.Title, Author: src.Name},
+			}, nil
+		})
+		Expect(err).ToNot(HaveOccurred())
+		authorField, err := enc.Resolver(func(ctx context.Context, src *Book) (Author, error) {
+			return Author{Name: src.Author}, nil
+		})
+		Expect(err).ToNot(HaveOccurred())
+
+		authorType, err := enc.Struct(Author{})
+		Expect(err).ToNot(HaveOccurred())
+		authorType.AddFieldConfig("books", &booksField)
+		bookType, err := enc.Struct(Book{})
+		Expect(err).ToNot(HaveOccurred())
+		bookType.AddFieldConfig("author", &authorField)
+
+		schema, err := graphql.NewSchema(graphql.SchemaConfig{
+			Query: graphql.NewObject(graphql.ObjectConfig{
+				Name: "Query",
+				Fields: graphql.Fields{
+					"author": &graphql.Field{
+						Type: authorType,
+						Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+							return Author{Name: "Machado"}, nil
+						},
+					},
+				},
+			}),
+		})
+		Expect(err).ToNot(HaveOccurred())
+
+		r := graphql.Do(graphql.Params{
+			Schema:        schema,
+			RequestString: `{ author { books(title: "Dom Casmurro", address: { city: "Rio" }) { title author { name } } } }`,
+		})
+		Expect(r.Errors).To(BeEmpty())
+		Expect(received.Title).To(Equal("Dom Casmurro"))
+		Expect(received.Limit).To(Equal(10))
+		Expect(received.Address).To(Equal(&Address{City: "Rio"}))
+		Expect(r.Data).To(Equal(map[string]interface{}{
+			"author": map[string]interface{}{
+				"books": []interface{}{
+					map[string]interface{}{
+						"title": "Dom Casmurro",
+						"author": map[string]interface{}{
+							"name": "Machado",
+						},
+					},
+				},
+			},
+		}))
+
+		r = graphql.Do(graphql.Params{
+			Schema:        schema,
+			RequestString: `{ author { books { title } } }`,
+		})
+		Expect(r.Errors).To(HaveLen(1))
+		Expect(r.Errors[0].Message).To(Equal("title is required"))
+	})
+
+	It("should fail when the resolver is not a function", func() {
+		_, err := gqlstruct.NewEncoder().Resolver(Author{})
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("the resolver must be a function"))
+	})
+
+	It("should fail when the resolver is nil", func() {
+		_, err := gqlstruct.NewEncoder().Resolver(nil)
+		Expect(err).To(MatchError("the resolver must be a function, got nil"))
+
+		var fn func(context.Context, *Author) string
+		_, err = gqlstruct.NewEncoder().Resolver(fn)
+		Expect(err).To(MatchError("the resolver must be a function, got a nil func(context.Context, *gqlstruct_test.Author) string"))
+	})
+
+	It("should fail when the resolver does not receive a context", func() {
+		_, err := gqlstruct.NewEncoder().Resolver(func(src *Author, args BooksArgs) (*Book, error) {
+			return nil, nil
+		})
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("must receive a context.Context"))
+	})
+
+	It("should fail when the resolver does not return a value", func() {
+		_, err := gqlstruct.NewEncoder().Resolver(func(ctx context.Context, src *Author) error {
+			return nil
+		})
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("must return a value"))
+	})
+
+	It("should fail when the arguments are not a struct", func() {
+		_, err := gqlstruct.NewEncoder().Resolver(func(ctx context.Context, src *Author, args string) (*Book, error) {
+			return nil, nil
+		})
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("cannot build args from a non struct"))
+	})
+
+	It("should panic using the global encoder with an invalid resolver", func() {
+		Expect(func() {
+			gqlstruct.Resolver(Author{})
+		}).To(Panic())
+	})
+})
@@ -0,0 +1,173 @@
+package gqlstruct
+
+import (
+	"errors"
+	"github.com/graphql-go/graphql"
+	"reflect"
+	"sort"
+)
+
+// Schema builds a `graphql.Schema` from the root structs informed. The
+// mutation and subscription are optional (nil).
+//
+// The root structs are described by `StructOf`, so their tagged fields and
+// methods (check `GraphqlMethods`) become the root fields. The root values
+// are used as the source of their fields:
+//
+// ```
+// type Query struct{}
+//
+// func (q *Query) GraphqlMethods() map[string]string {
+//     return map[string]string{
+//         "Hero": "hero",
+//     }
+// }
+//
+// func (q *Query) Hero(ctx context.Context, args HeroArgs) (*Person, error)
+//
+// schema, err := enc.Schema(&Query{}, nil, nil)
+// ```
+//
+// Every type built by the encoder is added to the `Types` of the schema. So,
+// the objects implementing interfaces are not lost.
+func (enc *encoder) Schema(query, mutation, subscription interface{}) (graphql.Schema, error) {
+	defer enc.lock()()
+	r, err := enc.schema(query, mutation, subscription)
+	if err := enc.endBuild(err); err != nil {
+		return graphql.Schema{}, err
+	}
+	return r, nil
+}
+
+// schema implements `Schema`, without locking the encoder.
+func (enc *encoder) schema(query, mutation, subscription interface{}) (graphql.Schema, error) {
+	if query == nil {
+		return graphql.Schema{}, errors.New("the query root is required")
+	}
+
+	var cfg graphql.SchemaConfig
+	roots := make(map[graphql.Type]*graphql.Object)
+	var err error
+	if cfg.Query, err = enc.rootOf(query, roots); err != nil {
+		return graphql.Schema{}, err
+	}
+	if cfg.Mutation, err = enc.rootOf(mutation, roots); err != nil {
+		return graphql.Schema{}, err
+	}
+	if cfg.Subscription, err = enc.rootOf(subscription, roots); err != nil {
+		return graphql.Schema{}, err
+	}
+	if len(enc.errs) > 0 {
+		// The types lack the fields skipped by the aggregated errors, so the
+		// schema is not built.
+		return graphql.Schema{}, nil
+	}
+	cfg.Types = enc.namedTypes()
+	for i, t := range cfg.Types {
+		if root, ok := roots[t]; ok {
+			// The objects of the roots are replaced by the objects bound to
+			// the root values of this schema.
+			cfg.Types[i] = root
+		}
+	}
+	return graphql.NewSchema(cfg)
+}
+
+// rootOf builds the object of a root struct, setting the root value as the
+// source of its fields.
+//
+// The object built by the encoder is shared by every schema of the root
+// type, so the root value is bound to a copy of it. The copy is recorded in
+// roots, by the object it was copied from.
+func (enc *encoder) rootOf(root interface{}, roots map[graphql.Type]*graphql.Object) (*graphql.Object, error) {
+	if root == nil {
+		return nil, nil
+	}
+
+	obj, err := enc.structOf(reflect.TypeOf(root))
+	if err != nil {
+		return nil, err
+	}
+	if r, ok := roots[obj]; ok {
+		// The same type is used by more than one root.
+		return r, nil
+	}
+
+	fields := make(graphql.Fields, len(obj.Fields()))
+	for name, field := range obj.Fields() {
+		args := make(graphql.FieldConfigArgument, len(field.Args))
+		for _, arg := range field.Args {
+			args[arg.Name()] = &graphql.ArgumentConfig{
+				Type:         arg.Type,
+				DefaultValue: arg.DefaultValue,
+				Description:  arg.Description(),
+			}
+		}
+		fields[name] = &graphql.Field{
+			Name:              field.Name,
+			Type:              field.Type,
+			Args:              args,
+			Resolve:           rootResolve(root, field.Resolve),
+			Subscribe:         field.Subscribe,
+			DeprecationReason: field.DeprecationReason,
+			Description:       field.Description,
+		}
+	}
+	r := graphql.NewObject(graphql.ObjectConfig{
+		Name:        obj.Name(),
+		Interfaces:  obj.Interfaces(),
+		Fields:      fields,
+		IsTypeOf:    obj.IsTypeOf,
+		Description: obj.Description(),
+	})
+	if policies := enc.fieldPolicies[obj]; policies != nil {
+		enc.setPolicies(r, policies)
+	}
+	roots[obj] = r
+	return r, nil
+}
+
+// Types returns all named types built by the encoder, sorted by name.
+func (enc *encoder) Types() []graphql.Type {
+	defer enc.lock()()
+	return enc.namedTypes()
+}
+
+// namedTypes implements `Types`, without locking the encoder.
+func (enc *encoder) namedTypes() []graphql.Type {
+	names := make([]string, 0, len(enc.names))
+	for name := range enc.names {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	r := make([]graphql.Type, len(names))
+	for i, name := range names {
+		r[i] = enc.names[name].gt
+	}
+	return r
+}
+
+// rootResolve creates a resolver that calls resolve with the root as the
+// source, unless the source already is a root value of the same type (as a
+// `RootObject` informed when executing).
+func rootResolve(root interface{}, resolve graphql.FieldResolveFn) graphql.FieldResolveFn {
+	if resolve == nil {
+		resolve = graphql.DefaultResolveFn
+	}
+	rootType := reflect.TypeOf(root)
+	return func(p graphql.ResolveParams) (interface{}, error) {
+		if reflect.TypeOf(p.Source) != rootType {
+			p.Source = root
+		}
+		return resolve(p)
+	}
+}
+
+func Schema(query, mutation, subscription interface{}) graphql.Schema {
+	r, err := defaultEncoder.Schema(query, mutation, subscription)
+	if err != nil {
+		panic(err.Error())
+	}
+	return r
+}
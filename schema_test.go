@@ -0,0 +1,227 @@
+package gqlstruct_test
+
+import (
+	"context"
+	"github.com/graphql-go/graphql"
+	"github.com/lab259/go-graphql-struct"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+type SchemaPerson struct {
+	Node    `graphql:",interface"`
+	Name    string         `graphql:"!name"`
+	Friends []SchemaPerson `graphql:"friends"`
+}
+
+type SchemaPersonArgs struct {
+	ID string `graphql:"!id"`
+}
+
+type SchemaCreatePersonArgs struct {
+	Name string `graphql:"!name"`
+}
+
+type SchemaQuery struct {
+	Version string `graphql:"version"`
+	people  map[string]*SchemaPerson
+}
+
+func (q *SchemaQuery) GraphqlMethods() map[string]string {
+	return map[string]string{
+		"Person": "person",
+	}
+}
+
+func (q *SchemaQuery) Person(args SchemaPersonArgs) *SchemaPerson {
+	return q.people[args.ID]
+}
+
+type SchemaMutation struct {
+	query *SchemaQuery
+}
+
+func (m *SchemaMutation) GraphqlMethods() map[string]string {
+	return map[string]string{
+		"CreatePerson": "createPerson",
+	}
+}
+
+func (m *SchemaMutation) CreatePerson(ctx context.Context, args SchemaCreatePersonArgs) (*SchemaPerson, error) {
+	person := &SchemaPerson{Name: args.Name}
+	m.query.people["new"] = person
+	return person, nil
+}
+
+type SchemaSubscription struct {
+	Counter int `graphql:"counter"`
+}
+
+// withSubscribe sets the function that subscribes to the events of the
+// field.
+type withSubscribe graphql.FieldResolveFn
+
+func (subscribe withSubscribe) ApplyField(field *graphql.Field) error {
+	field.Subscribe = graphql.FieldResolveFn(subscribe)
+	return nil
+}
+
+var _ = Describe("Schema", func() {
+	It("should build the schema from the root structs", func() {
+		query := &SchemaQuery{
+			Version: "1.0.0",
+			people: map[string]*SchemaPerson{
+				"1": {
+					Node: Node{ID: "1"},
+					Name: "Snake Eyes",
+					Friends: []SchemaPerson{
+						{Name: "Scarlett"},
+					},
+				},
+			},
+		}
+
+		enc := gqlstruct.NewEncoder()
+		schema, err := enc.Schema(query, &SchemaMutation{query: query}, nil)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(schema.QueryType().Name()).To(Equal("SchemaQuery"))
+		Expect(schema.MutationType().Name()).To(Equal("SchemaMutation"))
+		Expect(schema.SubscriptionType()).To(BeNil())
+		Expect(schema.Type("SchemaPerson")).ToNot(BeNil())
+		Expect(schema.Type("Node")).To(BeAssignableToTypeOf(&graphql.Interface{}))
+
+		r := graphql.Do(graphql.Params{
+			Schema:        schema,
+			RequestString: `{ version person(id: "1") { id name friends { name } } }`,
+		})
+		Expect(r.Errors).To(BeEmpty())
+		Expect(r.Data).To(Equal(map[string]interface{}{
+			"version": "1.0.0",
+			"person": map[string]interface{}{
+				"id":   "1",
+				"name": "Snake Eyes",
+				"friends": []interface{}{
+					map[string]interface{}{"name": "Scarlett"},
+				},
+			},
+		}))
+
+		r = graphql.Do(graphql.Params{
+			Schema:        schema,
+			RequestString: `mutation { createPerson(name: "Duke") { name } }`,
+		})
+		Expect(r.Errors).To(BeEmpty())
+		Expect(r.Data).To(Equal(map[string]interface{}{
+			"createPerson": map[string]interface{}{"name": "Duke"},
+		}))
+		Expect(query.people).To(HaveKey("new"))
+	})
+
+	It("should keep the implementations of the interfaces", func() {
+		enc := gqlstruct.NewEncoder()
+		_, err := enc.Struct(SchemaPerson{})
+		Expect(err).ToNot(HaveOccurred())
+		schema, err := enc.Schema(&SchemaQuery{}, nil, nil)
+		Expect(err).ToNot(HaveOccurred())
+		node := schema.Type("Node").(*graphql.Interface)
+		Expect(schema.PossibleTypes(node)).To(HaveLen(1))
+		Expect(schema.PossibleTypes(node)[0].Name()).To(Equal("SchemaPerson"))
+	})
+
+	It("should list the types built by the encoder sorted by name", func() {
+		enc := gqlstruct.NewEncoder()
+		_, err := enc.Struct(SchemaPerson{})
+		Expect(err).ToNot(HaveOccurred())
+		types := enc.Types()
+		Expect(types).To(HaveLen(2))
+		Expect(types[0].Name()).To(Equal("Node"))
+		Expect(types[1].Name()).To(Equal("SchemaPerson"))
+	})
+
+	It("should use the root value as the source of the root fields", func() {
+		enc := gqlstruct.NewEncoder()
+		schema, err := enc.Schema(&SchemaQuery{Version: "1"}, nil, nil)
+		Expect(err).ToNot(HaveOccurred())
+
+		r := graphql.Do(graphql.Params{
+			Schema:        schema,
+			RequestString: `{ version }`,
+		})
+		Expect(r.Data).To(Equal(map[string]interface{}{"version": "1"}))
+	})
+
+	It("should bind each schema to its own root value", func() {
+		enc := gqlstruct.NewEncoder()
+		one, err := enc.Schema(&SchemaQuery{Version: "1"}, nil, nil)
+		Expect(err).ToNot(HaveOccurred())
+		two, err := enc.Schema(&SchemaQuery{Version: "2"}, nil, nil)
+		Expect(err).ToNot(HaveOccurred())
+		_, err = enc.Schema(&SchemaQuery{Version: "3"}, nil, nil)
+		Expect(err).ToNot(HaveOccurred())
+
+		for version, schema := range map[string]graphql.Schema{"1": one, "2": two} {
+			r := graphql.Do(graphql.Params{
+				Schema:        schema,
+				RequestString: `{ version }`,
+			})
+			Expect(r.Errors).To(BeEmpty())
+			Expect(r.Data).To(Equal(map[string]interface{}{"version": version}))
+		}
+
+		obj, err := enc.Struct(SchemaQuery{})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(obj.Fields()["version"].Resolve(graphql.ResolveParams{Source: &SchemaQuery{Version: "4"}})).To(Equal("4"))
+	})
+
+	It("should keep the subscribers of the fields of the subscription root", func() {
+		enc := gqlstruct.NewEncoder()
+		_, err := enc.Struct(SchemaSubscription{}, gqlstruct.WithFieldOptions("counter", withSubscribe(func(p graphql.ResolveParams) (interface{}, error) {
+			events := make(chan interface{})
+			go func() {
+				defer close(events)
+				for i := 1; i <= 2; i++ {
+					events <- &SchemaSubscription{Counter: i}
+				}
+			}()
+			return events, nil
+		})))
+		Expect(err).ToNot(HaveOccurred())
+		schema, err := enc.Schema(&SchemaQuery{}, nil, &SchemaSubscription{})
+		Expect(err).ToNot(HaveOccurred())
+
+		var data []interface{}
+		for r := range graphql.Subscribe(graphql.Params{
+			Schema:        schema,
+			RequestString: `subscription { counter }`,
+		}) {
+			Expect(r.Errors).To(BeEmpty())
+			data = append(data, r.Data)
+		}
+		Expect(data).To(Equal([]interface{}{
+			map[string]interface{}{"counter": 1},
+			map[string]interface{}{"counter": 2},
+		}))
+	})
+
+	It("should fail without the query root", func() {
+		_, err := gqlstruct.NewEncoder().Schema(nil, &SchemaMutation{}, nil)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("the query root is required"))
+	})
+
+	It("should fail when a root cannot be built", func() {
+		type InvalidQuery struct {
+			Field1 []interface{} `graphql:"field1"`
+		}
+
+		_, err := gqlstruct.NewEncoder().Schema(&SchemaQuery{}, &InvalidQuery{}, nil)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("not recognized"))
+	})
+
+	It("should panic using the global encoder without the query root", func() {
+		Expect(func() {
+			gqlstruct.Schema(nil, nil, nil)
+		}).To(Panic())
+	})
+})
@@ -0,0 +1,385 @@
+package gqlstruct
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/graphql-go/graphql"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// specifiedScalars are the scalars defined by the GraphQL specification, they
+// are not printed.
+var specifiedScalars = map[string]bool{
+	"String":  true,
+	"Int":     true,
+	"Float":   true,
+	"Boolean": true,
+	"ID":      true,
+}
+
+// PrintSDL returns the GraphQL SDL of all types built by the encoder (and
+// the types referenced by them).
+//
+// The output is deterministic: types, fields, arguments and values are
+// sorted by name. So, it can be committed and diffed.
+func (enc *encoder) PrintSDL() string {
+	// Reading the fields and interfaces of the types initializes them, so
+	// the encoder is locked.
+	defer enc.lock()()
+
+	types := make(map[string]graphql.Type)
+	for _, t := range enc.namedTypes() {
+		collectTypes(t, types)
+	}
+	sdl := printTypes(types, enc.printPolicies)
+	if enc.hasPolicies(types) {
+		// The policies of the fields are printed as directives.
+		sdl = authDirective + "\n\n" + sdl
+	}
+	return sdl
+}
+
+// PrintSchema returns the GraphQL SDL of the schema informed, with the
+// default encoder (check the encoder `PrintSchema`).
+func PrintSchema(schema graphql.Schema) string {
+	return defaultEncoder.PrintSchema(schema)
+}
+
+// PrintSchema returns the GraphQL SDL of the schema informed. The policies
+// of the fields are printed for the objects built by the encoder.
+//
+// The output is deterministic: types, fields, arguments and values are
+// sorted by name. So, it can be committed and diffed.
+func (enc *encoder) PrintSchema(schema graphql.Schema) string {
+	defer enc.lock()()
+
+	types := make(map[string]graphql.Type)
+	for name, t := range schema.TypeMap() {
+		if strings.HasPrefix(name, "__") {
+			// Introspection types are not printed.
+			continue
+		}
+		types[name] = t
+	}
+
+	var b strings.Builder
+	if enc.hasPolicies(types) {
+		// The policies of the fields are printed as directives.
+		b.WriteString(authDirective + "\n\n")
+	}
+
+	query, mutation, subscription := schema.QueryType(), schema.MutationType(), schema.SubscriptionType()
+	if (query != nil && query.Name() != "Query") ||
+		(mutation != nil && mutation.Name() != "Mutation") ||
+		(subscription != nil && subscription.Name() != "Subscription") {
+		// The schema definition is omitted when the roots follow the
+		// convention.
+		b.WriteString("schema {\n")
+		if query != nil {
+			fmt.Fprintf(&b, "  query: %s\n", query.Name())
+		}
+		if mutation != nil {
+			fmt.Fprintf(&b, "  mutation: %s\n", mutation.Name())
+		}
+		if subscription != nil {
+			fmt.Fprintf(&b, "  subscription: %s\n", subscription.Name())
+		}
+		b.WriteString("}\n\n")
+	}
+	b.WriteString(printTypes(types, enc.printPolicies))
+	return b.String()
+}
+
+// collectTypes adds the named type t, and all types referenced by it, to
+// types.
+func collectTypes(t graphql.Type, types map[string]graphql.Type) {
+	named, ok := graphql.GetNamed(t).(graphql.Type)
+	if !ok || named == nil {
+		return
+	}
+	if _, ok := types[named.Name()]; ok {
+		return
+	}
+	types[named.Name()] = named
+
+	switch t := named.(type) {
+	case *graphql.Object:
+		for _, iface := range t.Interfaces() {
+			collectTypes(iface, types)
+		}
+		collectFieldTypes(t.Fields(), types)
+	case *graphql.Interface:
+		collectFieldTypes(t.Fields(), types)
+	case *graphql.Union:
+		for _, obj := range t.Types() {
+			collectTypes(obj, types)
+		}
+	case *graphql.InputObject:
+		for _, field := range t.Fields() {
+			collectTypes(field.Type, types)
+		}
+	}
+}
+
+func collectFieldTypes(fields graphql.FieldDefinitionMap, types map[string]graphql.Type) {
+	for _, field := range fields {
+		collectTypes(field.Type, types)
+		for _, arg := range field.Args {
+			collectTypes(arg.Type, types)
+		}
+	}
+}
+
+// printTypes prints the types sorted by name, separated by an empty line.
+// The directives of the fields of the objects are printed by directives, if
+// informed.
+func printTypes(types map[string]graphql.Type, directives fieldDirectives) string {
+	names := make([]string, 0, len(types))
+	for name := range types {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	defs := make([]string, 0, len(names))
+	for _, name := range names {
+		if def := printType(types[name], directives); def != "" {
+			defs = append(defs, def)
+		}
+	}
+	if len(defs) == 0 {
+		return ""
+	}
+	return strings.Join(defs, "\n\n") + "\n"
+}
+
+// fieldDirectives prints the directives of the field of the object.
+type fieldDirectives func(obj *graphql.Object, field string) string
+
+func printType(t graphql.Type, directives fieldDirectives) string {
+	var b strings.Builder
+	switch t := t.(type) {
+	case *graphql.Scalar:
+		if specifiedScalars[t.Name()] {
+			return ""
+		}
+		printDescription(&b, t.Description(), "")
+		fmt.Fprintf(&b, "scalar %s", t.Name())
+	case *graphql.Object:
+		printDescription(&b, t.Description(), "")
+		fmt.Fprintf(&b, "type %s", t.Name())
+		if len(t.Interfaces()) > 0 {
+			names := make([]string, len(t.Interfaces()))
+			for i, iface := range t.Interfaces() {
+				names[i] = iface.Name()
+			}
+			sort.Strings(names)
+			fmt.Fprintf(&b, " implements %s", strings.Join(names, " & "))
+		}
+		printFields(&b, t.Fields(), func(field string) string {
+			if directives == nil {
+				return ""
+			}
+			return directives(t, field)
+		})
+	case *graphql.Interface:
+		printDescription(&b, t.Description(), "")
+		fmt.Fprintf(&b, "interface %s", t.Name())
+		printFields(&b, t.Fields(), nil)
+	case *graphql.Union:
+		printDescription(&b, t.Description(), "")
+		names := make([]string, len(t.Types()))
+		for i, obj := range t.Types() {
+			names[i] = obj.Name()
+		}
+		sort.Strings(names)
+		fmt.Fprintf(&b, "union %s = %s", t.Name(), strings.Join(names, " | "))
+	case *graphql.Enum:
+		printDescription(&b, t.Description(), "")
+		fmt.Fprintf(&b, "enum %s {\n", t.Name())
+		values := append([]*graphql.EnumValueDefinition{}, t.Values()...)
+		sort.Slice(values, func(i, j int) bool {
+			return values[i].Name < values[j].Name
+		})
+		for _, value := range values {
+			printDescription(&b, value.Description, "  ")
+			fmt.Fprintf(&b, "  %s%s\n", value.Name, printDeprecated(value.DeprecationReason))
+		}
+		b.WriteString("}")
+	case *graphql.InputObject:
+		printDescription(&b, t.Description(), "")
+		fmt.Fprintf(&b, "input %s {\n", t.Name())
+		fields := t.Fields()
+		names := make([]string, 0, len(fields))
+		for name := range fields {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			field := fields[name]
+			printDescription(&b, field.Description(), "  ")
+			fmt.Fprintf(&b, "  %s: %s%s\n", name, field.Type, printDefault(field.DefaultValue, field.Type))
+		}
+		b.WriteString("}")
+	default:
+		return ""
+	}
+	return b.String()
+}
+
+func printFields(b *strings.Builder, fields graphql.FieldDefinitionMap, directives func(field string) string) {
+	names := make([]string, 0, len(fields))
+	for name := range fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	b.WriteString(" {\n")
+	for _, name := range names {
+		field := fields[name]
+		printDescription(b, field.Description, "  ")
+		suffix := printDeprecated(field.DeprecationReason)
+		if directives != nil {
+			suffix += directives(name)
+		}
+		fmt.Fprintf(b, "  %s%s: %s%s\n", name, printArgs(field.Args), field.Type, suffix)
+	}
+	b.WriteString("}")
+}
+
+func printArgs(args []*graphql.Argument) string {
+	if len(args) == 0 {
+		return ""
+	}
+
+	args = append([]*graphql.Argument{}, args...)
+	sort.Slice(args, func(i, j int) bool {
+		return args[i].Name() < args[j].Name()
+	})
+
+	multiline := false
+	for _, arg := range args {
+		if arg.Description() != "" {
+			multiline = true
+		}
+	}
+
+	var b strings.Builder
+	b.WriteString("(")
+	for i, arg := range args {
+		if multiline {
+			b.WriteString("\n")
+			printDescription(&b, arg.Description(), "    ")
+			b.WriteString("    ")
+		} else if i > 0 {
+			b.WriteString(", ")
+		}
+		fmt.Fprintf(&b, "%s: %s%s", arg.Name(), arg.Type, printDefault(arg.DefaultValue, arg.Type))
+	}
+	if multiline {
+		b.WriteString("\n  ")
+	}
+	b.WriteString(")")
+	return b.String()
+}
+
+func printDescription(b *strings.Builder, description, indent string) {
+	if description == "" {
+		return
+	}
+	description = strings.Replace(description, `"""`, `\"""`, -1)
+	if !strings.Contains(description, "\n") {
+		fmt.Fprintf(b, "%s\"\"\"%s\"\"\"\n", indent, description)
+		return
+	}
+	fmt.Fprintf(b, "%s\"\"\"\n", indent)
+	for _, line := range strings.Split(description, "\n") {
+		if line == "" {
+			b.WriteString("\n")
+			continue
+		}
+		fmt.Fprintf(b, "%s%s\n", indent, line)
+	}
+	fmt.Fprintf(b, "%s\"\"\"\n", indent)
+}
+
+func printDeprecated(reason string) string {
+	if reason == "" {
+		return ""
+	}
+	return fmt.Sprintf(" @deprecated(reason: %s)", printString(reason))
+}
+
+func printDefault(value interface{}, t graphql.Input) string {
+	if value == nil {
+		return ""
+	}
+	return " = " + printValue(value, t)
+}
+
+// printValue prints the internal value as a GraphQL literal of the type t.
+func printValue(value interface{}, t graphql.Input) string {
+	if nonNull, ok := t.(*graphql.NonNull); ok {
+		t = nonNull.OfType
+	}
+
+	if value == nil {
+		return "null"
+	}
+
+	switch t := t.(type) {
+	case *graphql.Enum:
+		if name, ok := t.Serialize(value).(string); ok {
+			return name
+		}
+	case *graphql.List:
+		v := reflect.ValueOf(value)
+		if v.Kind() != reflect.Slice && v.Kind() != reflect.Array {
+			return printValue(value, t.OfType)
+		}
+		items := make([]string, v.Len())
+		for i := range items {
+			items[i] = printValue(v.Index(i).Interface(), t.OfType)
+		}
+		return "[" + strings.Join(items, ", ") + "]"
+	case *graphql.InputObject:
+		m, ok := value.(map[string]interface{})
+		if !ok {
+			break
+		}
+		fields := t.Fields()
+		names := make([]string, 0, len(m))
+		for name := range m {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		items := make([]string, 0, len(names))
+		for _, name := range names {
+			field, ok := fields[name]
+			if !ok {
+				continue
+			}
+			items = append(items, fmt.Sprintf("%s: %s", name, printValue(m[name], field.Type)))
+		}
+		return "{" + strings.Join(items, ", ") + "}"
+	case *graphql.Scalar:
+		value = t.Serialize(value)
+	}
+
+	switch v := value.(type) {
+	case string:
+		return printString(v)
+	case nil:
+		return "null"
+	}
+	return fmt.Sprint(value)
+}
+
+func printString(s string) string {
+	data, err := json.Marshal(s)
+	if err != nil {
+		return fmt.Sprintf("%q", s)
+	}
+	return string(data)
+}
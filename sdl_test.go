@@ -0,0 +1,153 @@
+package gqlstruct_test
+
+import (
+	"github.com/graphql-go/graphql"
+	"github.com/lab259/go-graphql-struct"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"reflect"
+	"time"
+)
+
+type SDLAccount struct {
+	Node      `graphql:",interface"`
+	Name      string    `graphql:"!name,desc=The name of the account."`
+	Login     string    `graphql:"login,deprecated=Use name."`
+	Status    Status    `graphql:"status"`
+	CreatedAt time.Time `graphql:"createdAt"`
+	Tags      []string  `graphql:"tags"`
+}
+
+type SDLAccountsArgs struct {
+	First  int       `graphql:"first,default=10"`
+	Status Status    `graphql:"status,default=ACTIVE"`
+	Filter SDLFilter `graphql:"filter"`
+	Search *string   `graphql:"search"`
+}
+
+type SDLFilter struct {
+	Name string `graphql:"!name,desc=Exact \"name\" of the account."`
+}
+
+type SDLQuery struct {
+	Version string `graphql:"version"`
+}
+
+func (q *SDLQuery) GraphqlMethods() map[string]string {
+	return map[string]string{
+		"Accounts": "accounts",
+	}
+}
+
+func (q *SDLQuery) Accounts(args SDLAccountsArgs) []SDLAccount {
+	return nil
+}
+
+var _ = Describe("SDL", func() {
+	It("should print the types built by the encoder", func() {
+		enc := gqlstruct.NewEncoder()
+		_, err := enc.InterfaceOf(reflect.TypeOf(Node{}))
+		Expect(err).ToNot(HaveOccurred())
+		_, err = enc.Struct(&SDLQuery{})
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(enc.PrintSDL()).To(Equal(`"""The ` + "`DateTime`" + ` scalar type represents a DateTime. The DateTime is serialized as an RFC 3339 quoted string"""
+scalar DateTime
+
+interface Node {
+  id: String!
+}
+
+type SDLAccount implements Node {
+  createdAt: DateTime
+  id: String!
+  login: String @deprecated(reason: "Use name.")
+  """The name of the account."""
+  name: String!
+  status: Status
+  tags: [String]
+}
+
+input SDLFilterInput {
+  """Exact "name" of the account."""
+  name: String!
+}
+
+type SDLQuery {
+  accounts(filter: SDLFilterInput, first: Int = 10, search: String, status: Status = ACTIVE): [SDLAccount]
+  version: String
+}
+
+enum Status {
+  """The user can sign in."""
+  ACTIVE
+  BLOCKED
+  REMOVED @deprecated(reason: "Users are not removed anymore.")
+}
+`))
+	})
+	It("should print the schema definition for non conventional root names", func() {
+		enc := gqlstruct.NewEncoder()
+		schema, err := enc.Schema(&SDLQuery{}, nil, nil)
+		Expect(err).ToNot(HaveOccurred())
+
+		sdl := gqlstruct.PrintSchema(schema)
+		Expect(sdl).To(HavePrefix("schema {\n  query: SDLQuery\n}\n\n"))
+		Expect(sdl).To(ContainSubstring("type SDLAccount implements Node {"))
+		Expect(sdl).ToNot(ContainSubstring("__Schema"))
+		Expect(sdl).ToNot(ContainSubstring("scalar String"))
+	})
+
+	It("should omit the schema definition for conventional root names", func() {
+		query := graphql.NewObject(graphql.ObjectConfig{
+			Name: "Query",
+			Fields: graphql.Fields{
+				"hello": &graphql.Field{
+					Type:        graphql.String,
+					Description: "Says hello.\n\nIn english.",
+					Args: graphql.FieldConfigArgument{
+						"name": &graphql.ArgumentConfig{
+							Type:        graphql.NewNonNull(graphql.String),
+							Description: "Who is greeted.",
+						},
+						"times": &graphql.ArgumentConfig{
+							Type:         graphql.NewList(graphql.Int),
+							DefaultValue: []interface{}{1, 2},
+						},
+					},
+				},
+			},
+		})
+		schema, err := graphql.NewSchema(graphql.SchemaConfig{
+			Query: query,
+		})
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(gqlstruct.PrintSchema(schema)).To(Equal(`type Query {
+  """
+  Says hello.
+
+  In english.
+  """
+  hello(
+    """Who is greeted."""
+    name: String!
+    times: [Int] = [1, 2]
+  ): String
+}
+`))
+	})
+
+	It("should print the same SDL every time", func() {
+		enc := gqlstruct.NewEncoder()
+		_, err := enc.InterfaceOf(reflect.TypeOf(Node{}))
+		Expect(err).ToNot(HaveOccurred())
+		_, err = enc.Struct(&SDLQuery{})
+		Expect(err).ToNot(HaveOccurred())
+
+		sdl := enc.PrintSDL()
+		for i := 0; i < 10; i++ {
+			Expect(enc.PrintSDL()).To(Equal(sdl))
+		}
+	})
+})
@@ -6,131 +6,195 @@ import (
 	"reflect"
 )
 
-func newErrNotSupported(obj interface{}) error {
-	t := reflect.TypeOf(obj)
-	if t.Kind() == reflect.Ptr {
-		t = t.Elem()
-	}
-	if t.Kind() == reflect.Map {
-		return fmt.Errorf("`%s` is not supported", "map")
-	}
-	return fmt.Errorf("`%s` is not supported", t.Name())
+// StructConfig is the config of the objects built from structs, changed by
+// the `StructOption`s.
+type StructConfig struct {
+	graphql.ObjectConfig
+	// Methods are the tags of the methods exposed as fields, indexed by the
+	// name of the method (check `WithMethod`).
+	Methods map[string]string
+	// FieldOptions are the options of the fields, indexed by the GraphQL
+	// name of the field (check `WithFieldOptions`).
+	FieldOptions map[string][]FieldOption
+}
+
+// StructOption is an option of the objects built from structs (`Struct` and
+// `StructOf`). Besides the `ObjectOption`s, structs take options that only
+// apply to them, as `WithMethod` and `WithFieldOptions`.
+type StructOption interface {
+	ApplyStruct(cfg *StructConfig) error
+}
+
+// ObjectOption is an option of the types built by the encoder: objects
+// (`Struct` and `StructOf`), interfaces, unions, enums and input objects.
+//
+// The options are applied to a `*graphql.ObjectConfig`. Interfaces, unions,
+// enums and input objects only take the name and the description set by
+// them: options that set anything else fail.
+type ObjectOption interface {
+	StructOption
+	ApplyObject(cfg *graphql.ObjectConfig) error
+}
+
+// FieldOption is an option of fields (`Field`, `FieldOf` and `Resolver`).
+type FieldOption interface {
+	ApplyField(field *graphql.Field) error
+}
+
+// ArgOption is an option of arguments (`WithArgOptions`).
+type ArgOption interface {
+	ApplyArg(arg *graphql.ArgumentConfig) error
+}
+
+// EnumValueOption is an option of enum values (`NewEnumValue`).
+type EnumValueOption interface {
+	ApplyEnumValue(value *graphql.EnumValueConfig) error
+}
+
+// DescriptionOption is an option that can be applied to objects, fields,
+// arguments and enum values.
+type DescriptionOption interface {
+	ObjectOption
+	FieldOption
+	ArgOption
+	EnumValueOption
+}
+
+// DeprecationOption is an option that can be applied to fields and enum
+// values.
+type DeprecationOption interface {
+	FieldOption
+	EnumValueOption
 }
 
-// FieldOption describes how an option will behave when applied to a field.
-type Option interface {
-	Apply(dst interface{}) error
+// applyTypeOptions applies the options to the config of an object named
+// after the type, returning the name and the description set by them.
+//
+// The options that set anything else of the object config fail, as the type
+// is not an object.
+func applyTypeOptions(name string, options []ObjectOption) (string, string, error) {
+	cfg := graphql.ObjectConfig{
+		Name: name,
+	}
+	for _, option := range options {
+		if err := option.ApplyObject(&cfg); err != nil {
+			return "", "", err
+		}
+		if cfg.Fields != nil || cfg.Interfaces != nil || cfg.IsTypeOf != nil {
+			return "", "", fmt.Errorf("%s: the option %T can only be applied to objects", name, option)
+		}
+	}
+	return cfg.Name, cfg.Description, nil
 }
 
 type withDescription struct {
 	message string
 }
 
-// WithDescription creates an `Option` that provides sets the description for
-// fields, objects and arguments.
+// WithDescription creates an option that sets the description of objects,
+// fields, arguments and enum values.
 //
 // It can be applied to:
-// * Field;
-// * Arguments;
-// * Objects;
-func WithDescription(description string) Option {
+// * Objects, interfaces, unions, enums and input objects (`ObjectOption`);
+// * Fields (`FieldOption`);
+// * Arguments (`ArgOption`);
+// * Enum values (`EnumValueOption`);
+func WithDescription(description string) DescriptionOption {
 	return &withDescription{
 		message: description,
 	}
 }
 
-// Apply sets the description to the object.
-func (option *withDescription) Apply(dst interface{}) error {
-	switch t := dst.(type) {
-	case *graphql.Field:
-		t.Description = option.message
-		return nil
-	case *graphql.ArgumentConfig:
-		t.Description = option.message
-		return nil
-	case *graphql.ObjectConfig:
-		t.Description = option.message
-		return nil
-	default:
-		return newErrNotSupported(dst)
-	}
+// ApplyStruct sets the description of the object.
+func (option *withDescription) ApplyStruct(cfg *StructConfig) error {
+	return option.ApplyObject(&cfg.ObjectConfig)
+}
+
+// ApplyObject sets the description of the object.
+func (option *withDescription) ApplyObject(cfg *graphql.ObjectConfig) error {
+	cfg.Description = option.message
+	return nil
+}
+
+// ApplyField sets the description of the field.
+func (option *withDescription) ApplyField(field *graphql.Field) error {
+	field.Description = option.message
+	return nil
+}
+
+// ApplyArg sets the description of the argument.
+func (option *withDescription) ApplyArg(arg *graphql.ArgumentConfig) error {
+	arg.Description = option.message
+	return nil
+}
+
+// ApplyEnumValue sets the description of the enum value.
+func (option *withDescription) ApplyEnumValue(value *graphql.EnumValueConfig) error {
+	value.Description = option.message
+	return nil
 }
 
 type withDefaultvalue struct {
 	defaultValue interface{}
 }
 
-// WithDefaultvalue creates an `Option` that provides sets the description for
+// WithDefaultvalue creates an `ArgOption` that sets the default value of
 // arguments.
-//
-// It can be applied to:
-// * Arguments;
-func WithDefaultvalue(defaultValue interface{}) Option {
+func WithDefaultvalue(defaultValue interface{}) ArgOption {
 	return &withDefaultvalue{
 		defaultValue: defaultValue,
 	}
 }
 
-// Apply sets the default value to the argument.
-func (option *withDefaultvalue) Apply(dst interface{}) error {
-	switch t := dst.(type) {
-	case *graphql.ArgumentConfig:
-		t.DefaultValue = option.defaultValue
-		return nil
-	default:
-		return newErrNotSupported(dst)
-	}
+// ApplyArg sets the default value of the argument.
+func (option *withDefaultvalue) ApplyArg(arg *graphql.ArgumentConfig) error {
+	arg.DefaultValue = option.defaultValue
+	return nil
 }
 
 type withDeprecationReason struct {
 	message string
 }
 
-// WithDeprecationReason creates an `Option` that sets the deprecation reason
-// for fields.
+// WithDeprecationReason creates an option that sets the deprecation reason
+// of fields and enum values.
 //
 // It can be applied to:
-// * Fields;
-func WithDeprecationReason(description string) Option {
+// * Fields (`FieldOption`);
+// * Enum values (`EnumValueOption`);
+func WithDeprecationReason(description string) DeprecationOption {
 	return &withDeprecationReason{
 		message: description,
 	}
 }
 
-// Apply sets the deprecation reason to the object.
-func (option *withDeprecationReason) Apply(dst interface{}) error {
-	switch t := dst.(type) {
-	case *graphql.Field:
-		t.DeprecationReason = option.message
-		return nil
-	default:
-		return newErrNotSupported(dst)
-	}
+// ApplyField sets the deprecation reason of the field.
+func (option *withDeprecationReason) ApplyField(field *graphql.Field) error {
+	field.DeprecationReason = option.message
+	return nil
+}
+
+// ApplyEnumValue sets the deprecation reason of the enum value.
+func (option *withDeprecationReason) ApplyEnumValue(value *graphql.EnumValueConfig) error {
+	value.DeprecationReason = option.message
+	return nil
 }
 
 type withResolver struct {
 	resolver graphql.FieldResolveFn
 }
 
-// WithResolver creates an `Option` that sets the resolver for fields.
-//
-// It can be applied to:
-// * Fields;
-func WithResolve(resolver graphql.FieldResolveFn) Option {
+// WithResolve creates a `FieldOption` that sets the resolver of fields.
+func WithResolve(resolver graphql.FieldResolveFn) FieldOption {
 	return &withResolver{
 		resolver: resolver,
 	}
 }
 
-// Apply sets the deprecation reason to the object.
-func (option *withResolver) Apply(dst interface{}) error {
-	switch t := dst.(type) {
-	case *graphql.Field:
-		t.Resolve = option.resolver
-		return nil
-	default:
-		return newErrNotSupported(dst)
-	}
+// ApplyField sets the resolver of the field.
+func (option *withResolver) ApplyField(field *graphql.Field) error {
+	field.Resolve = option.resolver
+	return nil
 }
 
 type withArgs struct {
@@ -138,67 +202,114 @@ type withArgs struct {
 	args    interface{}
 }
 
-// WithArgs creates an `Option` that sets the arguments for a field.
+// WithArgs creates a `FieldOption` that sets the arguments of fields,
+// described by the default encoder from the args struct (check `ArgsOf`).
 //
-// It can be applied to:
-// * Fields;
-func WithArgs(args ...interface{}) Option {
-	enc := defaultEncoder
-	var data interface{}
-	if len(args) == 2 {
-		tmp, ok := args[0].(*encoder)
-		if !ok {
-			panic("the first parameter of WithArgs must be an encoder")
-		}
-		enc = tmp
-		data = args[1]
-	} else if len(args) == 1 {
-		data = args[0]
-	} else {
-		panic("invalid usage")
-	}
+// Errors describing the arguments are returned when the option is applied.
+func WithArgs(args interface{}) FieldOption {
+	return defaultEncoder.WithArgs(args)
+}
+
+// WithArgs creates a `FieldOption` that sets the arguments of fields,
+// described by the encoder from the args struct (check `ArgsOf`).
+func (enc *encoder) WithArgs(args interface{}) FieldOption {
 	return &withArgs{
 		encoder: enc,
-		args:    data,
+		args:    args,
+	}
+}
+
+// ApplyField sets the arguments of the field.
+func (option *withArgs) ApplyField(field *graphql.Field) error {
+	args, err := option.encoder.Args(option.args)
+	if err != nil {
+		return err
 	}
+	field.Args = args
+	return nil
+}
+
+type withArgOptions struct {
+	arg     string
+	options []ArgOption
 }
 
-// Apply sets the arguments of a field.
-func (option *withArgs) Apply(dst interface{}) error {
-	switch t := dst.(type) {
-	case *graphql.Field:
-		args, err := option.encoder.Args(option.args)
-		if err != nil {
+// WithArgOptions creates a `FieldOption` that applies the options to an
+// argument of fields, identified by its name:
+//
+// ```
+// gqlstruct.Field(User{}, gqlstruct.WithArgs(UserArgs{}), gqlstruct.WithArgOptions("limit", gqlstruct.WithDefaultvalue(10)))
+// ```
+//
+// The argument must be already defined (as by `WithArgs`) when the option is
+// applied.
+func WithArgOptions(arg string, options ...ArgOption) FieldOption {
+	return &withArgOptions{
+		arg:     arg,
+		options: options,
+	}
+}
+
+// ApplyField applies the options to the argument of the field.
+func (option *withArgOptions) ApplyField(field *graphql.Field) error {
+	arg, ok := field.Args[option.arg]
+	if !ok {
+		return fmt.Errorf("the argument '%s' is not defined", option.arg)
+	}
+	for _, o := range option.options {
+		if err := o.ApplyArg(arg); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// applyFieldOptions applies the options to a field built by the encoder. The
+// arguments of `WithArgs` from the same encoder, and the connections and the
+// filters from the same encoder or from the package functions, are built
+// directly, as the encoder is already locked.
+func (enc *encoder) applyFieldOptions(field *graphql.Field, options []FieldOption) error {
+	for _, option := range options {
+		if o, ok := option.(*withArgs); ok && o.encoder == enc {
+			args, err := enc.argsOf(reflect.TypeOf(o.args))
+			if err != nil {
+				return err
+			}
+			field.Args = args
+			continue
+		}
+		if o, ok := option.(*withConnection); ok && (o.encoder == nil || o.encoder == enc) {
+			if err := enc.connectionField(field, o.resolve); err != nil {
+				return err
+			}
+			continue
+		}
+		if o, ok := option.(*withFilter); ok && (o.encoder == nil || o.encoder == enc) {
+			if err := enc.filterField(field, reflect.TypeOf(o.model)); err != nil {
+				return err
+			}
+			continue
+		}
+		if err := option.ApplyField(field); err != nil {
 			return err
 		}
-		t.Args = args
-		return nil
-	default:
-		return newErrNotSupported(dst)
 	}
+	return nil
 }
 
 type withType struct {
 	t graphql.Type
 }
 
-// WithType creates an `Option` that sets the type of fields.
-//
-// It can be applied to:
-// * Fields;
-func WithType(t graphql.Type) Option {
+// WithType creates a `FieldOption` that sets the type of fields.
+func WithType(t graphql.Type) FieldOption {
 	return &withType{
 		t: t,
 	}
 }
 
-// Apply sets the type of a field.
-func (option *withType) Apply(dst interface{}) error {
-	switch t := dst.(type) {
-	case *graphql.Field:
-		t.Type = option.t
-		return nil
-	default:
-		return newErrNotSupported(dst)
-	}
+// ApplyField sets the type of the field.
+func (option *withType) ApplyField(field *graphql.Field) error {
+	field.Type = option.t
+	return nil
 }
@@ -10,73 +10,126 @@ import (
 
 type erroredOption struct{}
 
-func (*erroredOption) Apply(dst interface{}) error {
+func (*erroredOption) ApplyStruct(cfg *gqlstruct.StructConfig) error {
 	return errors.New("forced error")
 }
 
+func (*erroredOption) ApplyObject(cfg *graphql.ObjectConfig) error {
+	return errors.New("forced error")
+}
+
+func (*erroredOption) ApplyField(field *graphql.Field) error {
+	return errors.New("forced error")
+}
+
+type isTypeOfOption struct{}
+
+func (option *isTypeOfOption) ApplyStruct(cfg *gqlstruct.StructConfig) error {
+	return option.ApplyObject(&cfg.ObjectConfig)
+}
+
+func (*isTypeOfOption) ApplyObject(cfg *graphql.ObjectConfig) error {
+	cfg.IsTypeOf = func(p graphql.IsTypeOfParams) bool {
+		return true
+	}
+	return nil
+}
+
 var _ = Describe("Sugar", func() {
 	Describe("Description", func() {
 		It("should apply the description to a field", func() {
 			field := graphql.Field{}
-			err := gqlstruct.WithDescription("Description 1").Apply(&field)
+			err := gqlstruct.WithDescription("Description 1").ApplyField(&field)
 			Expect(err).ToNot(HaveOccurred())
 			Expect(field.Description).To(Equal("Description 1"))
 		})
 
 		It("should apply the description to an argument", func() {
 			argument := graphql.ArgumentConfig{}
-			err := gqlstruct.WithDescription("Description 1").Apply(&argument)
+			err := gqlstruct.WithDescription("Description 1").ApplyArg(&argument)
 			Expect(err).ToNot(HaveOccurred())
 			Expect(argument.Description).To(Equal("Description 1"))
 		})
 
 		It("should apply the description to an object", func() {
 			obj := graphql.ObjectConfig{}
-			err := gqlstruct.WithDescription("Description 1").Apply(&obj)
+			err := gqlstruct.WithDescription("Description 1").ApplyObject(&obj)
 			Expect(err).ToNot(HaveOccurred())
 			Expect(obj.Description).To(Equal("Description 1"))
 		})
 
-		It("should fail applying the description to a not supported object", func() {
-			obj := map[string]interface{}{}
-			err := gqlstruct.WithDescription("Description 1").Apply(&obj)
-			Expect(err).To(HaveOccurred())
-			Expect(err.Error()).To(ContainSubstring("is not supported"))
-			Expect(err.Error()).To(ContainSubstring("map"))
+		It("should apply the description to an enum value", func() {
+			value := graphql.EnumValueConfig{}
+			err := gqlstruct.WithDescription("Description 1").ApplyEnumValue(&value)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(value.Description).To(Equal("Description 1"))
 		})
 	})
 
 	Describe("DefaultValue", func() {
 		It("should apply the default value to an argument", func() {
 			argument := graphql.ArgumentConfig{}
-			err := gqlstruct.WithDefaultvalue("default value").Apply(&argument)
+			err := gqlstruct.WithDefaultvalue("default value").ApplyArg(&argument)
 			Expect(err).ToNot(HaveOccurred())
 			Expect(argument.DefaultValue).To(Equal("default value"))
 		})
 
-		It("should fail applying the default value to a not supported object", func() {
-			field := graphql.Field{}
-			err := gqlstruct.WithDefaultvalue("default value").Apply(&field)
+		It("should only be an argument option", func() {
+			var option interface{} = gqlstruct.WithDefaultvalue("default value")
+			_, ok := option.(gqlstruct.FieldOption)
+			Expect(ok).To(BeFalse())
+			_, ok = option.(gqlstruct.ObjectOption)
+			Expect(ok).To(BeFalse())
+		})
+	})
+
+	Describe("ArgOptions", func() {
+		type User struct {
+			Name string `graphql:"name"`
+		}
+
+		type UserArgs struct {
+			Limit int `graphql:"limit"`
+		}
+
+		It("should apply the options to the argument", func() {
+			field, err := gqlstruct.NewEncoder().Field(&User{},
+				gqlstruct.WithArgs(UserArgs{}),
+				gqlstruct.WithArgOptions("limit", gqlstruct.WithDescription("The limit."), gqlstruct.WithDefaultvalue(10)),
+			)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(field.Args["limit"].Description).To(Equal("The limit."))
+			Expect(field.Args["limit"].DefaultValue).To(Equal(10))
+		})
+
+		It("should fail when the argument is not defined", func() {
+			_, err := gqlstruct.NewEncoder().Field(&User{},
+				gqlstruct.WithArgOptions("limit", gqlstruct.WithDefaultvalue(10)),
+			)
 			Expect(err).To(HaveOccurred())
-			Expect(err.Error()).To(ContainSubstring("is not supported"))
-			Expect(err.Error()).To(ContainSubstring("Field"))
+			Expect(err.Error()).To(Equal("the argument 'limit' is not defined"))
 		})
 	})
 
 	Describe("DeprecationReason", func() {
 		It("should apply the deprecation reason to a field", func() {
 			field := graphql.Field{}
-			err := gqlstruct.WithDeprecationReason("deprecation reason").Apply(&field)
+			err := gqlstruct.WithDeprecationReason("deprecation reason").ApplyField(&field)
 			Expect(err).ToNot(HaveOccurred())
 			Expect(field.DeprecationReason).To(Equal("deprecation reason"))
 		})
 
-		It("should fail applying the deprecation reason to a not supported object", func() {
-			argument := graphql.ArgumentConfig{}
-			err := gqlstruct.WithDeprecationReason("default value").Apply(&argument)
-			Expect(err).To(HaveOccurred())
-			Expect(err.Error()).To(ContainSubstring("is not supported"))
-			Expect(err.Error()).To(ContainSubstring("ArgumentConfig"))
+		It("should apply the deprecation reason to an enum value", func() {
+			value := graphql.EnumValueConfig{}
+			err := gqlstruct.WithDeprecationReason("deprecation reason").ApplyEnumValue(&value)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(value.DeprecationReason).To(Equal("deprecation reason"))
+		})
+
+		It("should not be an argument option", func() {
+			var option interface{} = gqlstruct.WithDeprecationReason("deprecation reason")
+			_, ok := option.(gqlstruct.ArgOption)
+			Expect(ok).To(BeFalse())
 		})
 	})
 
@@ -85,31 +138,21 @@ var _ = Describe("Sugar", func() {
 			field := graphql.Field{}
 			err := gqlstruct.WithResolve(func(p graphql.ResolveParams) (interface{}, error) {
 				return nil, nil
-			}).Apply(&field)
+			}).ApplyField(&field)
 			Expect(err).ToNot(HaveOccurred())
 			Expect(field.Resolve).NotTo(BeNil())
 		})
-
-		It("should fail applying the resolver to a not supported object", func() {
-			argument := graphql.ArgumentConfig{}
-			err := gqlstruct.WithResolve(func(p graphql.ResolveParams) (interface{}, error) {
-				return nil, nil
-			}).Apply(&argument)
-			Expect(err).To(HaveOccurred())
-			Expect(err.Error()).To(ContainSubstring("is not supported"))
-			Expect(err.Error()).To(ContainSubstring("ArgumentConfig"))
-		})
 	})
 
 	Describe("Args", func() {
-		It("should apply the arguments to a field", func() {
-			type Args struct {
-				Name string `graphql:"name"`
-				Age  int    `graphql:"age"`
-			}
+		type Args struct {
+			Name string `graphql:"name"`
+			Age  int    `graphql:"age"`
+		}
 
+		It("should apply the arguments to a field", func() {
 			field := graphql.Field{}
-			err := gqlstruct.WithArgs(Args{}).Apply(&field)
+			err := gqlstruct.WithArgs(Args{}).ApplyField(&field)
 			Expect(err).ToNot(HaveOccurred())
 			Expect(field.Args).To(HaveLen(2))
 			Expect(field.Args).To(HaveKey("name"))
@@ -119,41 +162,31 @@ var _ = Describe("Sugar", func() {
 		It("should apply the arguments to a field with a custom encoder", func() {
 			enc := gqlstruct.NewEncoder()
 
-			type Args struct {
-				Name string `graphql:"name"`
-				Age  int    `graphql:"age"`
-			}
-
 			field := graphql.Field{}
-			err := gqlstruct.WithArgs(enc, Args{}).Apply(&field)
+			err := enc.WithArgs(Args{}).ApplyField(&field)
 			Expect(err).ToNot(HaveOccurred())
 			Expect(field.Args).To(HaveLen(2))
 			Expect(field.Args).To(HaveKey("name"))
 			Expect(field.Args).To(HaveKey("age"))
 		})
 
-		It("should panic when the first args is not a encoder", func() {
-			type Args struct {
+		It("should apply the arguments of the same encoder while building a field", func() {
+			type Author struct {
 				Name string `graphql:"name"`
-				Age  int    `graphql:"age"`
 			}
 
-			field := graphql.Field{}
-			Expect(func() {
-				gqlstruct.WithArgs(Args{}, Args{}).Apply(&field)
-			}).To(Panic())
-		})
+			enc := gqlstruct.NewEncoder()
+			field, err := enc.Field(Author{}, enc.WithArgs(Args{}))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(field.Args).To(HaveLen(2))
 
-		It("should panic when the call with more than 2 arguments", func() {
-			field := graphql.Field{}
-			Expect(func() {
-				gqlstruct.WithArgs(1, 2, 3).Apply(&field)
-			}).To(Panic())
+			field = gqlstruct.Field(Author{}, gqlstruct.WithArgs(Args{}))
+			Expect(field.Args).To(HaveLen(2))
 		})
 
 		It("should fail due to the arg is not a struct", func() {
 			field := graphql.Field{}
-			err := gqlstruct.WithArgs([]interface{}{}).Apply(&field)
+			err := gqlstruct.WithArgs([]interface{}{}).ApplyField(&field)
 			Expect(err).To(HaveOccurred())
 			Expect(err.Error()).To(ContainSubstring("cannot build args from a non struct"))
 		})
@@ -164,23 +197,11 @@ var _ = Describe("Sugar", func() {
 			}
 
 			field := graphql.Field{}
-			err := gqlstruct.WithArgs(Args{}).Apply(&field)
+			err := gqlstruct.WithArgs(Args{}).ApplyField(&field)
 			Expect(err).To(HaveOccurred())
 			Expect(err.Error()).To(ContainSubstring("not recognized"))
 			Expect(err.Error()).To(ContainSubstring("interface {}"))
 		})
-
-		It("should fail when not applying to a field", func() {
-			type Args struct {
-				Field1 []interface{} `graphql:"field1"`
-			}
-
-			obj := graphql.ObjectConfig{}
-			err := gqlstruct.WithArgs(Args{}).Apply(&obj)
-			Expect(err).To(HaveOccurred())
-			Expect(err.Error()).To(ContainSubstring("not supported"))
-			Expect(err.Error()).To(ContainSubstring("ObjectConfig"))
-		})
 	})
 
 	Describe("Type", func() {
@@ -191,17 +212,9 @@ var _ = Describe("Sugar", func() {
 
 		It("should apply the type to a field", func() {
 			field := graphql.Field{}
-			err := gqlstruct.WithType(t).Apply(&field)
+			err := gqlstruct.WithType(t).ApplyField(&field)
 			Expect(err).ToNot(HaveOccurred())
 			Expect(field.Type).To(Equal(t))
 		})
-
-		It("should fail applying the resolver to a not supported object", func() {
-			argument := graphql.ArgumentConfig{}
-			err := gqlstruct.WithType(t).Apply(&argument)
-			Expect(err).To(HaveOccurred())
-			Expect(err.Error()).To(ContainSubstring("is not supported"))
-			Expect(err.Error()).To(ContainSubstring("ArgumentConfig"))
-		})
 	})
 })
@@ -0,0 +1,194 @@
+package gqlstruct
+
+import (
+	"errors"
+	"fmt"
+	"github.com/graphql-go/graphql"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Tag is the information extracted from the "graphql" tag of a field. It is
+// exported so tools (as code generators) describe the fields the same way
+// the encoder does.
+type Tag struct {
+	// Name is the name of the field.
+	Name string
+	// NonNull is set when the name starts with "!" or by the "nonnull"
+	// option.
+	NonNull bool
+	// Interface is set by the "interface" option. It marks an embedded
+	// struct as a GraphQL interface.
+	Interface bool
+	// Connection is set by the "connection" option. It describes a list
+	// field as a Relay connection (check `WithConnection`).
+	Connection bool
+	// Description is set by the "desc" option.
+	Description string
+	// DeprecationReason is set by the "deprecated" option.
+	DeprecationReason string
+	// Default is set by the "default" option. It is parsed accordingly to
+	// the type of the field by `parseDefaultValue`.
+	Default    string
+	HasDefault bool
+}
+
+var nameRegExp = regexp.MustCompile("^[_a-zA-Z][_a-zA-Z0-9]*$")
+
+// ParseTag extracts the information of the "graphql" tag. The tag is
+// formatted as the name of the field followed by comma separated options:
+//
+// ```
+// `graphql:"!name,desc=The name of the user,deprecated=Use fullName"`
+// ```
+//
+// The options available are:
+//
+// * nonnull: The field is NonNull, the same as starting the name with "!";
+// * desc=<description>: The description of the field;
+// * deprecated=<reason>: The deprecation reason of the field;
+// * default=<value>: The default value of an argument or input field;
+// * interface: The embedded struct is an interface (the name is omitted);
+// * connection: The list field is described as a Relay connection.
+//
+// Commas can be used in the values when escaped by a backslash ("\,").
+func ParseTag(tag string) (Tag, error) {
+	var r Tag
+
+	parts := splitTag(tag)
+	r.Name = parts[0]
+	// If the tag starts with "!" it is a NonNull type.
+	if len(r.Name) > 0 && r.Name[0] == '!' {
+		r.NonNull = true
+		r.Name = r.Name[1:]
+	}
+
+	for _, option := range parts[1:] {
+		key, value, hasValue := option, "", false
+		if idx := strings.Index(option, "="); idx > -1 {
+			key, value, hasValue = option[:idx], option[idx+1:], true
+		}
+
+		switch key {
+		case "nonnull", "interface", "connection":
+			if hasValue {
+				return r, fmt.Errorf("option '%s' does not accept a value", key)
+			}
+			switch key {
+			case "nonnull":
+				r.NonNull = true
+			case "interface":
+				r.Interface = true
+			default:
+				r.Connection = true
+			}
+		case "desc", "deprecated":
+			if value == "" {
+				return r, fmt.Errorf("option '%s' requires a value", key)
+			}
+			if key == "desc" {
+				r.Description = value
+			} else {
+				r.DeprecationReason = value
+			}
+		case "default":
+			if !hasValue {
+				return r, fmt.Errorf("option '%s' requires a value", key)
+			}
+			r.Default, r.HasDefault = value, true
+		case "":
+			return r, errors.New("empty option")
+		default:
+			return r, fmt.Errorf("unknown option '%s'", key)
+		}
+	}
+
+	if r.Interface {
+		if r.Name != "" {
+			return r, errors.New("interfaces cannot be named")
+		}
+		return r, nil
+	}
+
+	if r.Name == "" {
+		return r, errors.New("missing name")
+	}
+	if !nameRegExp.MatchString(r.Name) {
+		return r, fmt.Errorf("'%s' is not a valid name", r.Name)
+	}
+	return r, nil
+}
+
+// splitTag splits the tag by the commas that are not escaped.
+func splitTag(tag string) []string {
+	parts := make([]string, 0, 1)
+	var part strings.Builder
+	for i := 0; i < len(tag); i++ {
+		switch {
+		case tag[i] == '\\' && i+1 < len(tag) && (tag[i+1] == ',' || tag[i+1] == '\\'):
+			i++
+			part.WriteByte(tag[i])
+		case tag[i] == ',':
+			parts = append(parts, part.String())
+			part.Reset()
+		default:
+			part.WriteByte(tag[i])
+		}
+	}
+	return append(parts, part.String())
+}
+
+// hasTagOption checks if the tag has the option (with or without a value).
+func hasTagOption(tag, option string) bool {
+	for _, part := range splitTag(tag)[1:] {
+		if part == option || strings.HasPrefix(part, option+"=") {
+			return true
+		}
+	}
+	return false
+}
+
+// parseDefaultValue converts the default value of the tag accordingly to the
+// type of the field. Enum default values are referenced by their names.
+func parseDefaultValue(fieldType reflect.Type, inputType graphql.Input, value string) (interface{}, error) {
+	if nonNull, ok := inputType.(*graphql.NonNull); ok {
+		inputType = nonNull.OfType
+	}
+	if enum, ok := inputType.(*graphql.Enum); ok {
+		for _, enumValue := range enum.Values() {
+			if enumValue.Name == value {
+				return enumValue.Value, nil
+			}
+		}
+		return nil, fmt.Errorf("'%s' is not a value of %s", value, enum)
+	}
+
+	if fieldType.Kind() == reflect.Ptr {
+		fieldType = fieldType.Elem()
+	}
+
+	switch fieldType.Kind() {
+	case reflect.Bool:
+		return strconv.ParseBool(value)
+	case reflect.String:
+		return value, nil
+	case reflect.Int, reflect.Int64, reflect.Int32, reflect.Int16, reflect.Int8:
+		// The value must fit the field, so it is parsed by its size.
+		n, err := strconv.ParseInt(value, 10, fieldType.Bits())
+		if err != nil {
+			return nil, err
+		}
+		return int(n), nil
+	case reflect.Uint, reflect.Uint64, reflect.Uint32, reflect.Uint16, reflect.Uint8:
+		n, err := strconv.ParseUint(value, 10, fieldType.Bits())
+		if err != nil {
+			return nil, err
+		}
+		return uint(n), nil
+	case reflect.Float32, reflect.Float64:
+		return strconv.ParseFloat(value, 64)
+	}
+	return nil, fmt.Errorf("default values are not supported by %s", fieldType)
+}
@@ -0,0 +1,250 @@
+package gqlstruct_test
+
+import (
+	"github.com/graphql-go/graphql"
+	"github.com/lab259/go-graphql-struct"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"reflect"
+)
+
+var _ = Describe("Tags", func() {
+	Describe("Struct", func() {
+		It("should apply the options of the tag to the fields", func() {
+			type User struct {
+				Name     string `graphql:"name,nonnull,desc=The name of the user"`
+				Email    string `graphql:"email,desc=Primary email\\, used to sign in,deprecated=use contacts"`
+				Nickname string `graphql:"!nickname"`
+			}
+
+			obj, err := gqlstruct.NewEncoder().Struct(User{})
+			Expect(err).ToNot(HaveOccurred())
+			fields := obj.Fields()
+			Expect(fields).To(HaveLen(3))
+			Expect(fields["name"].Type.String()).To(Equal("String!"))
+			Expect(fields["name"].Description).To(Equal("The name of the user"))
+			Expect(fields["email"].Type.String()).To(Equal("String"))
+			Expect(fields["email"].Description).To(Equal("Primary email, used to sign in"))
+			Expect(fields["email"].DeprecationReason).To(Equal("use contacts"))
+			Expect(fields["nickname"].Type.String()).To(Equal("String!"))
+		})
+
+		It("should fail with a default value", func() {
+			type User struct {
+				Name string `graphql:"name,default=John"`
+			}
+
+			_, err := gqlstruct.NewEncoder().Struct(User{})
+			Expect(err).To(HaveOccurred())
+			Expect(err).To(BeAssignableToTypeOf(&gqlstruct.InvalidTagError{}))
+			Expect(err.Error()).To(ContainSubstring("User.Name: invalid tag `name,default=John`"))
+			Expect(err.Error()).To(ContainSubstring("default values are only supported by arguments and input fields"))
+		})
+
+		It("should fail with an interface that is not embedded", func() {
+			type User struct {
+				Node Node `graphql:",interface"`
+			}
+
+			_, err := gqlstruct.NewEncoder().Struct(User{})
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("User.Node"))
+			Expect(err.Error()).To(ContainSubstring("only embedded structs can be interfaces"))
+		})
+	})
+
+	Describe("Args", func() {
+		It("should apply the options of the tag to the arguments", func() {
+			type Args struct {
+				First   int     `graphql:"first,default=10,desc=The number of items"`
+				Ratio   float64 `graphql:"ratio,default=0.5"`
+				Active  *bool   `graphql:"active,default=true"`
+				Order   string  `graphql:"order,nonnull,default=name"`
+				Status  Status  `graphql:"status,default=BLOCKED"`
+				Keyword string  `graphql:"keyword"`
+			}
+
+			args, err := gqlstruct.NewEncoder().ArgsOf(reflect.TypeOf(Args{}))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(args).To(HaveLen(6))
+			Expect(args["first"].DefaultValue).To(Equal(10))
+			Expect(args["first"].Description).To(Equal("The number of items"))
+			Expect(args["ratio"].DefaultValue).To(Equal(0.5))
+			Expect(args["active"].DefaultValue).To(Equal(true))
+			Expect(args["order"].Type.String()).To(Equal("String!"))
+			Expect(args["order"].DefaultValue).To(Equal("name"))
+			Expect(args["status"].DefaultValue).To(Equal(StatusBlocked))
+			Expect(args["keyword"].DefaultValue).To(BeNil())
+		})
+
+		It("should apply the options of the tag to input objects", func() {
+			type Filter struct {
+				Limit int `graphql:"limit,default=20,desc=The limit"`
+			}
+
+			obj, err := gqlstruct.NewEncoder().InputObject(Filter{})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(obj.Fields()["limit"].DefaultValue).To(Equal(20))
+			Expect(obj.Fields()["limit"].Description()).To(Equal("The limit"))
+		})
+
+		It("should use the default value when the argument is not informed", func() {
+			type Args struct {
+				First int `graphql:"first,default=10"`
+			}
+
+			args, err := gqlstruct.NewEncoder().ArgsOf(reflect.TypeOf(Args{}))
+			Expect(err).ToNot(HaveOccurred())
+
+			schema, err := graphql.NewSchema(graphql.SchemaConfig{
+				Query: graphql.NewObject(graphql.ObjectConfig{
+					Name: "Query",
+					Fields: graphql.Fields{
+						"first": &graphql.Field{
+							Type: graphql.Int,
+							Args: args,
+							Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+								return p.Args["first"], nil
+							},
+						},
+					},
+				}),
+			})
+			Expect(err).ToNot(HaveOccurred())
+
+			r := graphql.Do(graphql.Params{
+				Schema:        schema,
+				RequestString: `{ first }`,
+			})
+			Expect(r.Errors).To(BeEmpty())
+			Expect(r.Data).To(Equal(map[string]interface{}{"first": 10}))
+		})
+
+		It("should fail with an invalid default value", func() {
+			type Args struct {
+				First int `graphql:"first,default=ten"`
+			}
+
+			_, err := gqlstruct.NewEncoder().ArgsOf(reflect.TypeOf(Args{}))
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("Args.First: invalid tag `first,default=ten`"))
+			Expect(err.Error()).To(ContainSubstring("invalid syntax"))
+		})
+
+		It("should parse the default values by the size of the integers", func() {
+			type Args struct {
+				Small  int8   `graphql:"small,default=-128"`
+				Count  uint16 `graphql:"count,default=65535"`
+				Offset int64  `graphql:"offset,default=-9000000000"`
+			}
+
+			args, err := gqlstruct.NewEncoder().ArgsOf(reflect.TypeOf(Args{}))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(args["small"].DefaultValue).To(Equal(-128))
+			Expect(args["count"].DefaultValue).To(Equal(uint(65535)))
+			Expect(args["offset"].DefaultValue).To(Equal(-9000000000))
+		})
+
+		It("should fail with default values that do not fit the integers", func() {
+			type Small struct {
+				Small int8 `graphql:"small,default=128"`
+			}
+			type Count struct {
+				Count uint16 `graphql:"count,default=65536"`
+			}
+			type Negative struct {
+				Count uint `graphql:"count,default=-1"`
+			}
+
+			_, err := gqlstruct.NewEncoder().ArgsOf(reflect.TypeOf(Small{}))
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("Small.Small: invalid tag `small,default=128`"))
+			Expect(err.Error()).To(ContainSubstring("value out of range"))
+
+			_, err = gqlstruct.NewEncoder().ArgsOf(reflect.TypeOf(Count{}))
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("value out of range"))
+
+			_, err = gqlstruct.NewEncoder().ArgsOf(reflect.TypeOf(Negative{}))
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("Negative.Count: invalid tag `count,default=-1`"))
+			Expect(err.Error()).To(ContainSubstring("invalid syntax"))
+		})
+
+		It("should fail with an invalid enum default value", func() {
+			type Args struct {
+				Status Status `graphql:"status,default=UNKNOWN"`
+			}
+
+			_, err := gqlstruct.NewEncoder().ArgsOf(reflect.TypeOf(Args{}))
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("'UNKNOWN' is not a value of Status"))
+		})
+
+		It("should fail with a default value of a not supported type", func() {
+			type Args struct {
+				Tags []string `graphql:"tags,default=a"`
+			}
+
+			_, err := gqlstruct.NewEncoder().ArgsOf(reflect.TypeOf(Args{}))
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("default values are not supported by []string"))
+		})
+
+		It("should fail with a deprecated argument", func() {
+			type Args struct {
+				First int `graphql:"first,deprecated=use limit"`
+			}
+
+			_, err := gqlstruct.NewEncoder().ArgsOf(reflect.TypeOf(Args{}))
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("arguments and input fields cannot be deprecated"))
+		})
+
+		It("should fail with an argument described as a connection", func() {
+			type Args struct {
+				Tags []string `graphql:"tags,connection"`
+			}
+
+			_, err := gqlstruct.NewEncoder().ArgsOf(reflect.TypeOf(Args{}))
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("arguments and input fields cannot be connections"))
+		})
+	})
+
+	Describe("Malformed", func() {
+		malformed := map[string]string{
+			"":                  "missing name",
+			",nonnull":          "missing name",
+			"first-name":        "'first-name' is not a valid name",
+			"name,unknown":      "unknown option 'unknown'",
+			"name,,nonnull":     "empty option",
+			"name,nonnull=true": "option 'nonnull' does not accept a value",
+			"name,desc":         "option 'desc' requires a value",
+			"name,deprecated=":  "option 'deprecated' requires a value",
+			"name,default":      "option 'default' requires a value",
+			"name,interface":    "interfaces cannot be named",
+			"name,connection=1": "option 'connection' does not accept a value",
+		}
+
+		for tag, message := range malformed {
+			tag, message := tag, message
+
+			It("should fail with the tag `"+tag+"`", func() {
+				t := reflect.StructOf([]reflect.StructField{
+					{
+						Name: "Field1",
+						Type: reflect.TypeOf(""),
+						Tag:  reflect.StructTag(`graphql:"` + tag + `"`),
+					},
+				})
+
+				_, err := gqlstruct.NewEncoder().ArgsOf(t)
+				Expect(err).To(HaveOccurred())
+				Expect(err).To(BeAssignableToTypeOf(&gqlstruct.InvalidTagError{}))
+				Expect(err.Error()).To(ContainSubstring(".Field1: invalid tag"))
+				Expect(err.Error()).To(ContainSubstring(message))
+			})
+		}
+	})
+})
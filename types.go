@@ -15,29 +15,57 @@ type GraphqlTyped interface {
 }
 
 var (
-	graphqlTypedType    = reflect.TypeOf(new(GraphqlTyped)).Elem()
-	graphqlResolverType = reflect.TypeOf(new(GraphqlResolver)).Elem()
-	timeType            = reflect.TypeOf(time.Time{})
+	graphqlTypedType         = reflect.TypeOf(new(GraphqlTyped)).Elem()
+	graphqlResolverType      = reflect.TypeOf(new(GraphqlResolver)).Elem()
+	graphqlBatchResolverType = reflect.TypeOf(new(GraphqlBatchResolver)).Elem()
+	timeType                 = reflect.TypeOf(time.Time{})
 )
 
+// typedOf returns the `graphql.Type` provided by a type that implements the
+// `GraphqlTyped` interface, with a value or a pointer receiver.
+func typedOf(fieldType reflect.Type) (graphql.Type, bool) {
+	if fieldType.Kind() == reflect.Ptr {
+		fieldType = fieldType.Elem()
+	}
+	// We need the a pointer to the type to check if it implements the
+	// interface, considering methods with pointer receivers.
+	if fieldType.Kind() == reflect.Interface || !reflect.PtrTo(fieldType).Implements(graphqlTypedType) {
+		return nil, false
+	}
+	return reflect.New(fieldType).Interface().(GraphqlTyped).GraphqlType(), true
+}
+
+// scalarOf returns the builtin scalar `graphql.Type` that represents the
+// basic kind of the type informed.
+func scalarOf(fieldType reflect.Type) (graphql.Type, bool) {
+	// Special case: If the type is the time.Time type.
+	if fieldType == timeType {
+		return graphql.DateTime, true
+	}
+
+	switch fieldType.Kind() {
+	case reflect.Bool:
+		return graphql.Boolean, true
+	case reflect.String:
+		return graphql.String, true
+	case
+		reflect.Int, reflect.Int64, reflect.Int32, reflect.Int16, reflect.Int8,
+		reflect.Uint, reflect.Uint64, reflect.Uint32, reflect.Uint16, reflect.Uint8:
+		return graphql.Int, true
+	case
+		reflect.Float32, reflect.Float64, reflect.Complex64, reflect.Complex128:
+		return graphql.Float, true
+	}
+	return nil, false
+}
+
 func (enc *encoder) buildFieldType(fieldType reflect.Type) (graphql.Type, error) {
 	if r, ok := enc.getType(fieldType); ok {
 		return r, nil
 	}
 
-	if fieldType.Kind() == reflect.Struct && fieldType != timeType {
-		// If the type is a struct, we need the a pointer to that struct to
-		// check if it implements the interface.
-		tStruct := reflect.PtrTo(fieldType)
-		if tStruct.Implements(graphqlTypedType) {
-			vStruct := reflect.New(fieldType)
-			return vStruct.Interface().(GraphqlTyped).GraphqlType(), nil
-		}
-	}
-
-	if fieldType.Implements(graphqlTypedType) {
-		vStruct := reflect.New(fieldType.Elem())
-		return vStruct.Interface().(GraphqlTyped).GraphqlType(), nil
+	if r, ok := typedOf(fieldType); ok {
+		return r, nil
 	}
 
 	// Check if it is a pointer or interface...
@@ -46,27 +74,59 @@ func (enc *encoder) buildFieldType(fieldType reflect.Type) (graphql.Type, error)
 		fieldType = fieldType.Elem()
 	}
 
-	// Special case: If the type is the time.Time type.
-	if fieldType == timeType {
-		return graphql.DateTime, nil
+	if reflect.PtrTo(fieldType).Implements(graphqlEnumType) {
+		return enc.enumOf(fieldType)
+	}
+
+	if r, ok := scalarOf(fieldType); ok {
+		return r, nil
 	}
 
 	switch fieldType.Kind() {
 	case reflect.Struct:
-		return enc.StructOf(fieldType)
+		return enc.structOf(fieldType)
 	case reflect.Array, reflect.Slice:
-		return enc.ArrayOf(fieldType.Elem())
-	case reflect.Bool:
-		return graphql.Boolean, nil
-	case reflect.String:
-		return graphql.String, nil
-	case
-		reflect.Int, reflect.Int64, reflect.Int32, reflect.Int16, reflect.Int8,
-		reflect.Uint, reflect.Uint64, reflect.Uint32, reflect.Uint16, reflect.Uint8:
-		return graphql.Int, nil
-	case
-		reflect.Float32, reflect.Float64, reflect.Complex64, reflect.Complex128:
-		return graphql.Float, nil
+		return enc.arrayOf(fieldType.Elem())
+	}
+	return nil, NewErrTypeNotRecognized(fieldType)
+}
+
+// buildInputFieldType returns the `graphql.Input` that represents the type
+// informed when it is used as an argument or as a field of an input object.
+func (enc *encoder) buildInputFieldType(fieldType reflect.Type) (graphql.Input, error) {
+	if r, ok := enc.getInputType(fieldType); ok {
+		return r, nil
+	}
+
+	if r, ok := typedOf(fieldType); ok {
+		if !graphql.IsInputType(r) {
+			return nil, NewErrTypeNotRecognized(fieldType)
+		}
+		return r, nil
+	}
+
+	if fieldType.Kind() == reflect.Ptr {
+		fieldType = fieldType.Elem()
+	}
+
+	if reflect.PtrTo(fieldType).Implements(graphqlEnumType) {
+		return enc.enumOf(fieldType)
+	}
+
+	if r, ok := scalarOf(fieldType); ok {
+		return r, nil
+	}
+
+	switch fieldType.Kind() {
+	case reflect.Struct:
+		return enc.inputObjectOf(fieldType)
+	case reflect.Array, reflect.Slice:
+		defer enc.listPath()()
+		elemType, err := enc.buildInputFieldType(fieldType.Elem())
+		if err != nil {
+			return nil, err
+		}
+		return graphql.NewList(elemType), nil
 	}
 	return nil, NewErrTypeNotRecognized(fieldType)
 }
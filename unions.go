@@ -0,0 +1,136 @@
+package gqlstruct
+
+import (
+	"fmt"
+	"github.com/graphql-go/graphql"
+	"reflect"
+)
+
+// RegisterUnion builds a `*graphql.Union` from a Go interface and the
+// structs that implement it, registering it to the interface type. From now
+// on, every field of that interface type will be described as the union.
+//
+// The iface must be a pointer to the interface, usually a "sealed" interface
+// (an interface with an unexported marker method):
+//
+// ```
+// type SearchResult interface {
+//     isSearchResult()
+// }
+//
+// enc.RegisterUnion((*SearchResult)(nil), []interface{}{User{}, Group{}})
+// ```
+//
+// The `ResolveType` of the union is generated from the concrete Go type of
+// the value resolved.
+func (enc *encoder) RegisterUnion(iface interface{}, members []interface{}, options ...ObjectOption) (*graphql.Union, error) {
+	defer enc.lock()()
+	r, err := enc.registerUnion(iface, members, options...)
+	if err := enc.endBuild(err); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// registerUnion implements `RegisterUnion`, without locking the encoder.
+func (enc *encoder) registerUnion(iface interface{}, members []interface{}, options ...ObjectOption) (*graphql.Union, error) {
+	t := reflect.TypeOf(iface)
+	if t == nil || t.Kind() != reflect.Ptr || t.Elem().Kind() != reflect.Interface {
+		return nil, fmt.Errorf("the union must be a pointer to an interface")
+	}
+	t = t.Elem()
+
+	if t.Name() == "" {
+		return nil, fmt.Errorf("%s is not a named interface", t)
+	}
+
+	if r, ok := enc.getType(t); ok {
+		return nil, fmt.Errorf("%s was already registered as %s", t, r)
+	}
+
+	memberTypes := make([]reflect.Type, 0, len(members))
+	for _, member := range members {
+		mt := reflect.TypeOf(member)
+		if mt == nil {
+			return nil, fmt.Errorf("the members of %s cannot be nil", t)
+		}
+		if mt.Kind() == reflect.Ptr {
+			mt = mt.Elem()
+		}
+		if !mt.Implements(t) && !reflect.PtrTo(mt).Implements(t) {
+			return nil, fmt.Errorf("%s does not implement %s", mt, t)
+		}
+		memberTypes = append(memberTypes, mt)
+	}
+
+	types := make([]*graphql.Object, 0, len(members))
+	typesByGoType := make(map[reflect.Type]*graphql.Object, len(members))
+
+	unionCfg := graphql.UnionConfig{
+		Name: enc.config.typeName(t.Name()),
+		// The members are built after the union is registered, so they can
+		// have fields of the union type.
+		Types: graphql.UnionTypesThunk(func() []*graphql.Object {
+			return types
+		}),
+		ResolveType: resolveTypeOf(typesByGoType),
+	}
+
+	// Apply options
+	var err error
+	unionCfg.Name, unionCfg.Description, err = applyTypeOptions(unionCfg.Name, options)
+	if err != nil {
+		return nil, err
+	}
+
+	r := graphql.NewUnion(unionCfg)
+	if err := r.Error(); err != nil {
+		return nil, err
+	}
+	if err := enc.registerType(t, r); err != nil {
+		return nil, err
+	}
+
+	for _, mt := range memberTypes {
+		obj, err := enc.structOf(mt)
+		if err != nil {
+			return nil, err
+		}
+		types = append(types, obj)
+		typesByGoType[mt] = obj
+	}
+
+	// Checks the members, now that they are built.
+	r.Types()
+	if err := r.Error(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// UnionOf returns the `*graphql.Union` registered for the interface type
+// informed.
+func (enc *encoder) UnionOf(t reflect.Type) (*graphql.Union, error) {
+	defer enc.lock()()
+	return enc.unionOf(t)
+}
+
+// unionOf implements `UnionOf`, without locking the encoder.
+func (enc *encoder) unionOf(t reflect.Type) (*graphql.Union, error) {
+	r, ok := enc.getType(t)
+	if !ok {
+		return nil, fmt.Errorf("%s was not registered as an union", t)
+	}
+	if d, ok := r.(*graphql.Union); ok {
+		return d, nil
+	}
+	return nil, fmt.Errorf("%s is not an graphql.Union", r)
+}
+
+func RegisterUnion(iface interface{}, members []interface{}, options ...ObjectOption) *graphql.Union {
+	r, err := defaultEncoder.RegisterUnion(iface, members, options...)
+	if err != nil {
+		panic(err.Error())
+	}
+	return r
+}
@@ -0,0 +1,195 @@
+package gqlstruct_test
+
+import (
+	"github.com/graphql-go/graphql"
+	"github.com/lab259/go-graphql-struct"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"reflect"
+)
+
+type SearchResult interface {
+	isSearchResult()
+}
+
+type SearchUser struct {
+	Name string `graphql:"name"`
+}
+
+func (SearchUser) isSearchResult() {}
+
+type SearchGroup struct {
+	Title string `graphql:"title"`
+}
+
+func (*SearchGroup) isSearchResult() {}
+
+type TreeNode interface {
+	isTreeNode()
+}
+
+type TreeBranch struct {
+	Children []TreeNode `graphql:"children"`
+}
+
+func (TreeBranch) isTreeNode() {}
+
+type TreeLeaf struct {
+	Value int `graphql:"value"`
+}
+
+func (TreeLeaf) isTreeNode() {}
+
+type Search struct {
+	Best    SearchResult   `graphql:"best"`
+	Results []SearchResult `graphql:"results"`
+}
+
+var _ = Describe("Union", func() {
+	It("should generate an union from an interface", func() {
+		enc := gqlstruct.NewEncoder()
+		union, err := enc.RegisterUnion((*SearchResult)(nil), []interface{}{SearchUser{}, &SearchGroup{}}, gqlstruct.WithDescription("Description 1"))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(union.Name()).To(Equal("SearchResult"))
+		Expect(union.Description()).To(Equal("Description 1"))
+		Expect(union.Types()).To(HaveLen(2))
+		Expect(union.Types()[0].Name()).To(Equal("SearchUser"))
+		Expect(union.Types()[1].Name()).To(Equal("SearchGroup"))
+
+		u, err := enc.UnionOf(reflect.TypeOf((*SearchResult)(nil)).Elem())
+		Expect(err).ToNot(HaveOccurred())
+		Expect(u).To(Equal(union))
+	})
+
+	It("should use the union for fields and lists", func() {
+		enc := gqlstruct.NewEncoder()
+		union, err := enc.RegisterUnion((*SearchResult)(nil), []interface{}{SearchUser{}, SearchGroup{}})
+		Expect(err).ToNot(HaveOccurred())
+
+		obj, err := enc.Struct(Search{})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(obj.Fields()["best"].Type).To(Equal(union))
+		Expect(obj.Fields()["results"].Type.String()).To(Equal("[SearchResult]"))
+	})
+
+	It("should build members that reference the union", func() {
+		enc := gqlstruct.NewEncoder()
+		union, err := enc.RegisterUnion((*TreeNode)(nil), []interface{}{TreeBranch{}, TreeLeaf{}})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(union.Types()).To(HaveLen(2))
+
+		branch, err := enc.Struct(TreeBranch{})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(branch.Fields()["children"].Type.String()).To(Equal("[TreeNode]"))
+	})
+
+	It("should fail when the interface is not registered", func() {
+		_, err := gqlstruct.NewEncoder().Struct(Search{})
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("not recognized"))
+	})
+
+	It("should fail when a member does not implement the interface", func() {
+		type Other struct {
+			Name string `graphql:"name"`
+		}
+
+		_, err := gqlstruct.NewEncoder().RegisterUnion((*SearchResult)(nil), []interface{}{Other{}})
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("does not implement"))
+	})
+
+	It("should fail when the union is not a pointer to an interface", func() {
+		_, err := gqlstruct.NewEncoder().RegisterUnion(SearchUser{}, []interface{}{SearchUser{}})
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("must be a pointer to an interface"))
+	})
+
+	It("should fail with an unnamed interface", func() {
+		_, err := gqlstruct.NewEncoder().RegisterUnion((*interface{})(nil), []interface{}{SearchUser{}})
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("is not a named interface"))
+	})
+
+	It("should fail registering an union twice", func() {
+		enc := gqlstruct.NewEncoder()
+		_, err := enc.RegisterUnion((*SearchResult)(nil), []interface{}{SearchUser{}})
+		Expect(err).ToNot(HaveOccurred())
+		_, err = enc.RegisterUnion((*SearchResult)(nil), []interface{}{SearchUser{}})
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("was already registered"))
+	})
+
+	It("should fail getting an union not registered", func() {
+		_, err := gqlstruct.NewEncoder().UnionOf(reflect.TypeOf((*SearchResult)(nil)).Elem())
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("was not registered as an union"))
+	})
+
+	It("should resolve the type from the Go type", func() {
+		enc := gqlstruct.NewEncoder()
+		_, err := enc.RegisterUnion((*SearchResult)(nil), []interface{}{SearchUser{}, SearchGroup{}})
+		Expect(err).ToNot(HaveOccurred())
+		searchType, err := enc.Struct(Search{})
+		Expect(err).ToNot(HaveOccurred())
+
+		schema, err := graphql.NewSchema(graphql.SchemaConfig{
+			Query: graphql.NewObject(graphql.ObjectConfig{
+				Name: "Query",
+				Fields: graphql.Fields{
+					"search": &graphql.Field{
+						Type: searchType,
+						Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+							return &Search{
+								Best: &SearchGroup{Title: "Admins"},
+								Results: []SearchResult{
+									SearchUser{Name: "John"},
+									&SearchGroup{Title: "Admins"},
+								},
+							}, nil
+						},
+					},
+				},
+			}),
+		})
+		Expect(err).ToNot(HaveOccurred())
+
+		r := graphql.Do(graphql.Params{
+			Schema: schema,
+			RequestString: `{
+				search {
+					best { __typename }
+					results {
+						__typename
+						... on SearchUser { name }
+						... on SearchGroup { title }
+					}
+				}
+			}`,
+		})
+		Expect(r.Errors).To(BeEmpty())
+		Expect(r.Data).To(Equal(map[string]interface{}{
+			"search": map[string]interface{}{
+				"best": map[string]interface{}{
+					"__typename": "SearchGroup",
+				},
+				"results": []interface{}{
+					map[string]interface{}{
+						"__typename": "SearchUser",
+						"name":       "John",
+					},
+					map[string]interface{}{
+						"__typename": "SearchGroup",
+						"title":      "Admins",
+					},
+				},
+			},
+		}))
+	})
+
+	It("should panic registering an invalid union using the global encoder", func() {
+		Expect(func() {
+			gqlstruct.RegisterUnion(nil, nil)
+		}).To(Panic())
+	})
+})